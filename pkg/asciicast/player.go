@@ -0,0 +1,107 @@
+package asciicast
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// maxLineSize is the maximum size of a line in an asciicast file.
+const maxLineSize = 1024 * 1024
+
+// Option represents available playback options.
+type Option func(*opt)
+
+type opt struct {
+	speed         float64
+	idleTimeLimit time.Duration
+}
+
+// WithSpeed specifies the playback speed multiplier.
+// Default is 1.
+func WithSpeed(speed float64) Option {
+	return func(o *opt) {
+		o.speed = speed
+	}
+}
+
+// WithIdleTimeLimit specifies the maximum time to wait between events.
+// Default is no limit.
+func WithIdleTimeLimit(limit time.Duration) Option {
+	return func(o *opt) {
+		o.idleTimeLimit = limit
+	}
+}
+
+// Play reads an asciicast v2 file from r and writes the output events to w with the recorded timing.
+// Input and resize events are skipped.
+func Play(ctx context.Context, r io.Reader, w io.Writer, opts ...Option) error {
+	o := opt{speed: 1}
+
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read header: %w", err)
+		}
+
+		return fmt.Errorf("empty asciicast file")
+	}
+
+	var header Header
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return fmt.Errorf("failed to decode header: %w", err)
+	}
+
+	if header.Version != Version {
+		return fmt.Errorf("unsupported asciicast version: %d", header.Version)
+	}
+
+	var prev float64
+
+	for scanner.Scan() {
+		var event [3]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+
+		elapsed, ok1 := event[0].(float64)
+		typ, ok2 := event[1].(string)
+		data, ok3 := event[2].(string)
+
+		if !ok1 || !ok2 || !ok3 {
+			return fmt.Errorf("malformed event: %s", scanner.Text())
+		}
+
+		if typ != EventOutput {
+			continue
+		}
+
+		delay := time.Duration((elapsed - prev) / o.speed * float64(time.Second))
+		if o.idleTimeLimit > 0 && delay > o.idleTimeLimit {
+			delay = o.idleTimeLimit
+		}
+
+		prev = elapsed
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,161 @@
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Version is the version of the asciicast file format.
+const Version = 2
+
+// Event types of the asciicast v2 format.
+const (
+	EventOutput = "o"
+	EventInput  = "i"
+	EventResize = "r"
+)
+
+// Header is the first line of an asciicast v2 file.
+// See: https://docs.asciinema.org/manual/asciicast/v2/
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Command   string            `json:"command,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder writes the events of a terminal session in the asciicast v2 format.
+// It is safe for concurrent use.
+// Recording stops at the first error, which is returned by Err.
+type Recorder struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+	err   error
+}
+
+// NewRecorder writes the header to w and returns a Recorder writing the subsequent events to w.
+// The time of each event is relative to the call of NewRecorder.
+func NewRecorder(w io.Writer, header Header) (*Recorder, error) {
+	start := time.Now()
+
+	header.Version = Version
+	if header.Timestamp == 0 {
+		header.Timestamp = start.Unix()
+	}
+
+	b, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode header: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "%s\n", b); err != nil {
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+
+	return &Recorder{w: w, start: start}, nil
+}
+
+// WriteEvent writes an event of the type with the data.
+// After an error, no more events are written and the first error is returned.
+func (r *Recorder) WriteEvent(typ, data string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	elapsed := time.Since(r.start).Seconds()
+
+	b, err := json.Marshal([]interface{}{elapsed, typ, data})
+	if err != nil {
+		r.err = fmt.Errorf("failed to encode event: %w", err)
+
+		return r.err
+	}
+
+	if _, err := fmt.Fprintf(r.w, "%s\n", b); err != nil {
+		r.err = fmt.Errorf("failed to write event: %w", err)
+
+		return r.err
+	}
+
+	return nil
+}
+
+// Err returns the first error that stopped the recording, or nil if all events have been written.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.err
+}
+
+// Writer returns a writer that writes to w and records the written data as output events.
+func (r *Recorder) Writer(w io.Writer) io.Writer {
+	return &writer{recorder: r, w: w}
+}
+
+// Reader returns a reader that reads from rd and records the read data as input events.
+func (r *Recorder) Reader(rd io.Reader) io.Reader {
+	return &reader{recorder: r, r: rd}
+}
+
+// SizeQueue returns a TerminalSizeQueue that records each terminal size returned by queue as resize events.
+func (r *Recorder) SizeQueue(queue remotecommand.TerminalSizeQueue) remotecommand.TerminalSizeQueue {
+	return &sizeQueue{recorder: r, queue: queue}
+}
+
+// The wrappers below keep passing the data through after a recording error,
+// which is reported by Recorder.Err after the session.
+
+type writer struct {
+	recorder *Recorder
+	w        io.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if n > 0 {
+		_ = w.recorder.WriteEvent(EventOutput, string(p[:n]))
+	}
+
+	return n, err
+}
+
+type reader struct {
+	recorder *Recorder
+	r        io.Reader
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		_ = r.recorder.WriteEvent(EventInput, string(p[:n]))
+	}
+
+	return n, err
+}
+
+type sizeQueue struct {
+	recorder *Recorder
+	queue    remotecommand.TerminalSizeQueue
+}
+
+func (q *sizeQueue) Next() *remotecommand.TerminalSize {
+	size := q.queue.Next()
+	if size != nil {
+		_ = q.recorder.WriteEvent(EventResize, fmt.Sprintf("%dx%d", size.Width, size.Height))
+	}
+
+	return size
+}
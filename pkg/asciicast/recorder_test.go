@@ -0,0 +1,107 @@
+package asciicast
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// failingWriter fails after writing the given number of writes.
+type failingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+var errDiskFull = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.writes == 0 {
+		return 0, errDiskFull
+	}
+
+	w.writes--
+
+	return w.Buffer.Write(p)
+}
+
+type fixedSizeQueue struct {
+	size *remotecommand.TerminalSize
+}
+
+func (q *fixedSizeQueue) Next() *remotecommand.TerminalSize {
+	return q.size
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	var cast, out bytes.Buffer
+
+	recorder, err := NewRecorder(&cast, Header{Width: 80, Height: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := recorder.Writer(&out)
+	_, _ = w.Write([]byte("$ ls\n"))
+	_, _ = recorder.Reader(strings.NewReader("ls\n")).Read(make([]byte, 8))
+	recorder.SizeQueue(&fixedSizeQueue{size: &remotecommand.TerminalSize{Width: 100, Height: 30}}).Next()
+	_, _ = w.Write([]byte("file\n"))
+
+	if err := recorder.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	if got := strings.Count(cast.String(), "\n"); got != 5 {
+		t.Errorf("recorded %d lines, want header and 4 events:\n%s", got, cast.String())
+	}
+
+	var played bytes.Buffer
+	if err := Play(context.Background(), &cast, &played, WithSpeed(1000)); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+
+	if played.String() != out.String() {
+		t.Errorf("played %q, want %q", played.String(), out.String())
+	}
+}
+
+func TestRecorderStopsAtFirstError(t *testing.T) {
+	// the header and one event are written
+	cast := &failingWriter{writes: 2}
+
+	recorder, err := NewRecorder(cast, Header{Width: 80, Height: 24})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+
+	w := recorder.Writer(&out)
+
+	for _, s := range []string{"one\n", "two\n", "three\n"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatalf("Write() error = %v, want the data passed through", err)
+		}
+	}
+
+	if out.String() != "one\ntwo\nthree\n" {
+		t.Errorf("output = %q, want all the data", out.String())
+	}
+
+	if err := recorder.Err(); !errors.Is(err, errDiskFull) {
+		t.Errorf("Err() = %v, want %v", err, errDiskFull)
+	}
+
+	// writing is stopped even if the writer recovers
+	cast.writes = 10
+	if err := recorder.WriteEvent(EventOutput, "four\n"); !errors.Is(err, errDiskFull) {
+		t.Errorf("WriteEvent() = %v, want %v", err, errDiskFull)
+	}
+
+	if got := strings.Count(cast.String(), "\n"); got != 2 {
+		t.Errorf("recorded %d lines, want 2:\n%s", got, cast.String())
+	}
+}
@@ -0,0 +1,213 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/printers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	headerPrefix    = "# "
+	deletedAtHeader = "deleted-at: "
+	contextHeader   = "context: "
+	fileExtension   = ".yaml"
+	timestampLayout = "20060102T150405Z"
+)
+
+// unsafeChars matches characters not allowed in the directory and file names of backups.
+var unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`) //nolint:gochecknoglobals
+
+// Backup represents an object saved before the deletion along with its dependents.
+type Backup struct {
+	// Path is the path of the backup file.
+	Path string
+	// DeletedAt is the time when the object was deleted.
+	DeletedAt time.Time
+	// Context is the name of the kubeconfig context the object was deleted from.
+	Context string
+	// Objects are the deleted object followed by its dependents.
+	Objects []*unstructured.Unstructured
+}
+
+// DefaultDir returns the default directory of backups.
+func DefaultDir() string {
+	return filepath.Join(homedir.HomeDir(), ".kube", "fuzzy", "backups")
+}
+
+// Write saves the object and its dependents to a new backup file under <dir>/<context>/.
+// The server-managed fields are omitted from the saved objects except the UIDs and the owner references,
+// which Reown uses to re-own the restored dependents.
+func Write(dir, context string, obj *unstructured.Unstructured, dependents []*unstructured.Unstructured) (string, error) {
+	now := time.Now().UTC()
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "%s%s%s\n", headerPrefix, deletedAtHeader, now.Format(time.RFC3339))
+	fmt.Fprintf(&buf, "%s%s%s\n", headerPrefix, contextHeader, context)
+
+	for _, o := range append([]*unstructured.Unstructured{obj}, dependents...) {
+		b, err := yaml.Marshal(strip(o))
+		if err != nil {
+			return "", fmt.Errorf("failed to encode object: %w", err)
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(b)
+	}
+
+	contextDir := filepath.Join(dir, sanitize(context))
+
+	if err := os.MkdirAll(contextDir, 0o700); err != nil { //nolint:gomnd
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	name := strings.Join([]string{
+		now.Format(timestampLayout),
+		strings.ToLower(obj.GetKind()),
+		obj.GetNamespace(),
+		obj.GetName(),
+	}, "_")
+
+	path := filepath.Join(contextDir, sanitize(name)+fileExtension)
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil { //nolint:gomnd
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return path, nil
+}
+
+// List returns the backups under <dir>/<context>/ sorted by the deletion time, newest first.
+// If the context is empty, backups of all contexts are returned.
+func List(dir, context string) ([]*Backup, error) {
+	pattern := filepath.Join(dir, "*", "*"+fileExtension)
+	if context != "" {
+		pattern = filepath.Join(dir, sanitize(context), "*"+fileExtension)
+	}
+
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]*Backup, 0, len(paths))
+
+	for _, path := range paths {
+		b, err := Read(path)
+		if err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, b)
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].DeletedAt.After(backups[j].DeletedAt)
+	})
+
+	return backups, nil
+}
+
+// Read reads the backup file.
+func Read(path string) (*Backup, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	backup := &Backup{Path: path}
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, headerPrefix) {
+			break
+		}
+
+		header := strings.TrimPrefix(line, headerPrefix)
+
+		switch {
+		case strings.HasPrefix(header, deletedAtHeader):
+			backup.DeletedAt, err = time.Parse(time.RFC3339, strings.TrimPrefix(header, deletedAtHeader))
+			if err != nil {
+				return nil, fmt.Errorf("invalid backup %s: %w", path, err)
+			}
+		case strings.HasPrefix(header, contextHeader):
+			backup.Context = strings.TrimPrefix(header, contextHeader)
+		}
+	}
+
+	for _, doc := range bytes.Split(b, []byte("\n---\n")) {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, fmt.Errorf("invalid backup %s: %w", path, err)
+		}
+
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		backup.Objects = append(backup.Objects, obj)
+	}
+
+	if len(backup.Objects) == 0 {
+		return nil, fmt.Errorf("invalid backup %s: no objects", path)
+	}
+
+	return backup, nil
+}
+
+// strip returns a copy of the object to be re-created.
+// The node of a Pod is cleared so that the restored Pod is scheduled again.
+func strip(obj *unstructured.Unstructured) runtime.Object {
+	stripped, ok := printers.Strip(obj).(*unstructured.Unstructured)
+	if !ok {
+		return obj
+	}
+
+	stripped.SetUID(obj.GetUID())
+	stripped.SetOwnerReferences(obj.GetOwnerReferences())
+
+	if obj.GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Pod"}) {
+		unstructured.RemoveNestedField(stripped.Object, "spec", "nodeName")
+	}
+
+	return stripped
+}
+
+// Reown returns a copy of the saved object to be created.
+// The saved UID is cleared and the owner references to the restored objects are re-mapped
+// to their new UIDs given by the saved UIDs. Owner references to the other objects are kept.
+func Reown(saved *unstructured.Unstructured, uids map[types.UID]types.UID) *unstructured.Unstructured {
+	obj := saved.DeepCopy()
+	obj.SetUID("")
+
+	refs := obj.GetOwnerReferences()
+	for i := range refs {
+		if uid, ok := uids[refs[i].UID]; ok {
+			refs[i].UID = uid
+		}
+	}
+
+	obj.SetOwnerReferences(refs)
+
+	return obj
+}
+
+func sanitize(s string) string {
+	return unsafeChars.ReplaceAllString(s, "_")
+}
@@ -0,0 +1,116 @@
+package backup
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newObject(apiVersion, kind, name string, uid types.UID, owner *unstructured.Unstructured) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":            name,
+			"namespace":       "demo",
+			"resourceVersion": "42",
+		},
+		"status": map[string]interface{}{"phase": "Running"},
+	}}
+	obj.SetUID(uid)
+
+	if owner != nil {
+		controller := true
+		obj.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: owner.GetAPIVersion(),
+			Kind:       owner.GetKind(),
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+			Controller: &controller,
+		}})
+	}
+
+	return obj
+}
+
+func TestWriteRead(t *testing.T) {
+	rs := newObject("apps/v1", "ReplicaSet", "web-1234", "rs-uid", nil)
+	pod := newObject("v1", "Pod", "web-1234-abcd", "pod-uid", rs)
+	_ = unstructured.SetNestedField(pod.Object, "node-1", "spec", "nodeName")
+
+	path, err := Write(t.TempDir(), "kind-kind", rs, []*unstructured.Unstructured{pod})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	b, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if b.Context != "kind-kind" || b.DeletedAt.IsZero() {
+		t.Errorf("header = (%q, %v), want the context and the deletion time", b.Context, b.DeletedAt)
+	}
+
+	if len(b.Objects) != 2 {
+		t.Fatalf("read %d objects, want 2", len(b.Objects))
+	}
+
+	saved := b.Objects[1]
+
+	if saved.GetUID() != "pod-uid" {
+		t.Errorf("uid = %q, want the saved uid", saved.GetUID())
+	}
+
+	if refs := saved.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "rs-uid" {
+		t.Errorf("ownerReferences = %v, want the reference to the ReplicaSet", refs)
+	}
+
+	if _, found, _ := unstructured.NestedString(saved.Object, "spec", "nodeName"); found {
+		t.Error("spec.nodeName is saved, want it cleared")
+	}
+
+	if _, found := saved.Object["status"]; found {
+		t.Error("status is saved, want it omitted")
+	}
+
+	if saved.GetResourceVersion() != "" {
+		t.Errorf("resourceVersion = %q, want it omitted", saved.GetResourceVersion())
+	}
+}
+
+func TestReown(t *testing.T) {
+	deploy := newObject("apps/v1", "Deployment", "web", "deploy-uid", nil)
+	rs := newObject("apps/v1", "ReplicaSet", "web-1234", "rs-uid", deploy)
+
+	tests := []struct {
+		name string
+		uids map[types.UID]types.UID
+		want types.UID
+	}{
+		{name: "restored owner", uids: map[types.UID]types.UID{"deploy-uid": "new-uid"}, want: "new-uid"},
+		{name: "owner not restored", uids: map[types.UID]types.UID{}, want: "deploy-uid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reown(rs, tt.uids)
+
+			if got.GetUID() != "" {
+				t.Errorf("uid = %q, want it cleared", got.GetUID())
+			}
+
+			refs := got.GetOwnerReferences()
+			if len(refs) != 1 || refs[0].UID != tt.want {
+				t.Errorf("ownerReferences = %v, want the uid %q", refs, tt.want)
+			}
+
+			if !reflect.DeepEqual(rs.GetOwnerReferences()[0].UID, types.UID("deploy-uid")) {
+				t.Error("the saved object is modified")
+			}
+		})
+	}
+}
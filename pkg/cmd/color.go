@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	fuzzyprinters "github.com/d-kuro/kubectl-fuzzy/pkg/printers"
+	dockerterm "github.com/moby/term"
+)
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+
+	noColorEnvVar = "NO_COLOR"
+)
+
+func validColor(color string) bool {
+	switch color {
+	case colorAuto, colorAlways, colorNever:
+		return true
+	default:
+		return false
+	}
+}
+
+// useColor reports whether to colorize the output written to out.
+// In auto mode, output is colorized only if out is a terminal and NO_COLOR is not set.
+func useColor(color string, out io.Writer) bool {
+	switch color {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv(noColorEnvVar); ok {
+		return false
+	}
+
+	_, isTerminal := dockerterm.GetFdInfo(out)
+
+	return isTerminal
+}
+
+// validateColor ensures that the --color and --color-theme values are valid.
+func validateColor(color, theme string) error {
+	if !validColor(color) {
+		return fmt.Errorf("invalid --color value %q, must be one of auto|always|never", color)
+	}
+
+	if _, ok := fuzzyprinters.Themes()[theme]; !ok {
+		return fmt.Errorf("invalid --color-theme value %q, must be one of %s",
+			theme, strings.Join(fuzzyprinters.ThemeNames(), "|"))
+	}
+
+	return nil
+}
+
+// previewTheme returns the theme highlighting the preview window, or nil if the preview window is not colorized.
+// The preview window is always rendered on the terminal, so auto mode only honours NO_COLOR.
+func previewTheme(color, theme string) *fuzzyprinters.Theme {
+	if color == colorNever {
+		return nil
+	}
+
+	if _, ok := os.LookupEnv(noColorEnvVar); ok && color == colorAuto {
+		return nil
+	}
+
+	t := fuzzyprinters.Themes()[theme]
+
+	return &t
+}
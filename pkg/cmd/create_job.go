@@ -3,8 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
 	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
@@ -12,36 +12,65 @@ import (
 	"github.com/spf13/pflag"
 	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
 	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/scheme"
 )
 
 const (
 	exampleCreateJob = `
 	# Selecting a CronJob with the fuzzy finder and create job
-	# Only supported cronjob
 	# If a jobName is omitted, generated from cronJob name
 	kubectl fuzzy create job [jobName] --from=cronjob [flags]
+
+	# Selecting a finished Job with the fuzzy finder and create a job with the same spec
+	# If a jobName is omitted, generated from the selected job name
+	kubectl fuzzy create job [jobName] --from=job [flags]
+
+	# Create a job with a command
+	kubectl fuzzy create job jobName --image=busybox -- date
+
+	# Selecting a CronJob with the fuzzy finder and create job with overridden args and env
+	kubectl fuzzy create job --from=cronjob --args=--date=2021-01-01 --env=DEBUG=true [flags]
+
+	# Edit the job in $EDITOR before creating it
+	kubectl fuzzy create job --from=cronjob --edit [flags]
+
+	# Selecting a CronJob with the fuzzy finder, create job and follow the logs until the job finishes
+	kubectl fuzzy create job --from=cronjob --follow-logs [flags]
 `
+
+	createJobFromCronJob = "cronjob"
+	createJobFromJob     = "job"
 )
 
+// controllerLabels are the labels set by the job controller.
+// They are bound to the UID of the original job and must not be copied to a cloned job.
+var controllerLabels = []string{ //nolint:gochecknoglobals
+	"controller-uid",
+	"job-name",
+	batchv1.ControllerUidLabel,
+	batchv1.JobNameLabel,
+}
+
 // NewCmdCreateJob provides a cobra command wrapping CreateJobOptions.
 func NewCmdCreateJob(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
 	o := NewCreateJobOptions(config, streams)
 
 	cmd := &cobra.Command{
-		Use:           "job [NAME] --from=cronjob",
-		Short:         "Selecting a CronJob with the fuzzy finder and create job",
+		Use:           "job [NAME] --from=cronjob|job | NAME --image=image [-- COMMAND] [args...]",
+		Short:         "Selecting a CronJob or Job with the fuzzy finder and create job",
 		Example:       exampleCreateJob,
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		RunE: func(c *cobra.Command, args []string) error {
-			if err := o.Complete(c, args); err != nil {
+			if err := o.Complete(c, args, c.ArgsLenAtDash()); err != nil {
 				return err
 			}
 
@@ -55,7 +84,9 @@ func NewCmdCreateJob(config *genericclioptions.ConfigFlags, streams genericcliop
 
 	flags := cmd.Flags()
 	o.configFlags.AddFlags(flags)
+	o.printFlags.AddFlags(cmd)
 	o.AddFlags(flags)
+	cmdutil.AddDryRunFlag(cmd)
 
 	return cmd
 }
@@ -63,65 +94,88 @@ func NewCmdCreateJob(config *genericclioptions.ConfigFlags, streams genericcliop
 // CreateJobOptions provides information required to update
 // the current context on a user's KUBECONFIG.
 type CreateJobOptions struct {
-	configFlags       *genericclioptions.ConfigFlags
-	previewPrintFlags *genericclioptions.JSONYamlPrintFlags
-	printFlags        *genericclioptions.PrintFlags
+	configFlags *genericclioptions.ConfigFlags
+	printFlags  *genericclioptions.PrintFlags
 	genericclioptions.IOStreams
 
 	printObj func(obj runtime.Object) error
 
-	name string
-	from string
+	name    string
+	from    string
+	image   string
+	command []string
+
+	container string
+	env       []string
+	args      []string
+	setImage  string
+	suspend   bool
+	edit      bool
+
+	wait       bool
+	followLogs bool
+	timeout    time.Duration
+
+	dryRunStrategy cmdutil.DryRunStrategy
 
 	builder   *resource.Builder
 	jobClient batchv1client.JobsGetter
+	podClient coreclient.PodsGetter
 	namespace string
 
-	preview       bool
-	previewFormat string
-	rawPreview    bool
+	finder  finderFlags
+	preview previewFlags
 }
 
 // NewCreateJobOptions provides an instance of CreateJobOptions with default values.
 func NewCreateJobOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *CreateJobOptions {
 	return &CreateJobOptions{
-		configFlags:       config,
-		previewPrintFlags: genericclioptions.NewJSONYamlPrintFlags(),
-		printFlags:        genericclioptions.NewPrintFlags("created").WithTypeSetter(scheme.Scheme),
-		IOStreams:         streams,
+		configFlags: config,
+		printFlags:  genericclioptions.NewPrintFlags("created").WithTypeSetter(scheme.Scheme),
+		IOStreams:   streams,
 	}
 }
 
 // AddFlags adds a flag to the flag set.
 func (o *CreateJobOptions) AddFlags(flags *pflag.FlagSet) {
-	flags.StringVar(&o.from, "from", o.from, "The name of the resource to create a Job from (only cronjob is supported).")
+	flags.StringVar(&o.from, "from", o.from, "The name of the resource to create a Job from. One of cronjob|job.")
+	flags.StringVar(&o.image, "image", o.image, "Image name to run.")
 
 	// original flags
-	flags.BoolVarP(&o.preview, "preview", "P", false,
-		"If true, display the object YAML|JSON by preview window for fuzzy finder selector.")
-	flags.StringVar(&o.previewFormat, "preview-format", "yaml",
-		"Preview window output format. One of json|yaml.")
-	flags.BoolVar(&o.rawPreview, "raw-preview", false,
-		"If true, display the unsimplified object in the preview window. (default is simplified)")
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
+	flags.StringVarP(&o.container, "container", "c", "",
+		"Container name to apply --env, --args and --set-image to. If omitted, the first container is used.")
+	flags.StringArrayVar(&o.env, "env", nil,
+		"Environment variable to set in the container, in the form KEY=VAL. Can be specified multiple times.")
+	flags.StringArrayVar(&o.args, "args", nil,
+		"Argument to replace the args of the container with. Can be specified multiple times.")
+	flags.StringVar(&o.setImage, "set-image", "",
+		"Image to replace the image of the container with.")
+	flags.BoolVar(&o.suspend, "suspend", false,
+		"If true, create the job suspended.")
+	flags.BoolVar(&o.edit, "edit", false,
+		"If true, edit the job in $KUBE_EDITOR or $EDITOR before creating it.")
+	flags.BoolVar(&o.wait, "wait", false,
+		"If true, wait for the job to finish and exit with a non-zero status if the job failed.")
+	flags.BoolVar(&o.followLogs, "follow-logs", false,
+		"If true, stream the logs of the pods of the job as soon as the container starts. Implies --wait.")
+	flags.DurationVar(&o.timeout, "timeout", 0,
+		"The length of time to wait for the job to finish with --wait or --follow-logs, zero means wait forever.")
 }
 
 // Complete sets all information required for get logs.
-func (o *CreateJobOptions) Complete(cmd *cobra.Command, args []string) error {
+func (o *CreateJobOptions) Complete(cmd *cobra.Command, args []string, argsLenAtDash int) error {
 	client, err := kubernetes.NewClient(o.configFlags)
 	if err != nil {
 		return fmt.Errorf("failed to new Kubernetes client: %w", err)
 	}
 
-	if o.from == "" {
-		return fmt.Errorf("--from option is required, only supported job from cronjob")
-	}
+	o.preview.Complete()
 
-	if o.from != "cronjob" {
-		return fmt.Errorf("must specify resource, only supported cronjob")
-	}
-
-	if !o.preview {
-		o.preview, _ = strconv.ParseBool(os.Getenv(previewEnabledEnvVar))
+	if argsLenAtDash > -1 {
+		o.command = args[argsLenAtDash:]
+		args = args[:argsLenAtDash]
 	}
 
 	if len(args) >= 1 {
@@ -129,6 +183,7 @@ func (o *CreateJobOptions) Complete(cmd *cobra.Command, args []string) error {
 	}
 
 	o.jobClient = client.BatchV1()
+	o.podClient = client.CoreV1()
 	o.builder = resource.NewBuilder(o.configFlags)
 
 	kubeConfig := o.configFlags.ToRawKubeConfigLoader()
@@ -140,6 +195,13 @@ func (o *CreateJobOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	o.namespace = namespace
 
+	o.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	if err != nil {
+		return fmt.Errorf("faild to get dry-run strategy: %w", err)
+	}
+
+	cmdutil.PrintFlagsWithDryRunStrategy(o.printFlags, o.dryRunStrategy)
+
 	printer, err := o.printFlags.ToPrinter()
 	if err != nil {
 		return err
@@ -154,62 +216,307 @@ func (o *CreateJobOptions) Complete(cmd *cobra.Command, args []string) error {
 
 // Validate ensures that all required arguments and flag values are provided.
 func (o *CreateJobOptions) Validate() error {
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.finder.Validate(); err != nil {
+		return err
+	}
+
+	if o.from == "" && o.image == "" {
+		return fmt.Errorf("either --from or --image must be specified")
+	}
+
+	if o.from != "" && o.image != "" {
+		return fmt.Errorf("--from and --image are mutually exclusive")
+	}
+
+	if o.from != "" && o.from != createJobFromCronJob && o.from != createJobFromJob {
+		return fmt.Errorf("invalid --from value %q, must be one of %s|%s", o.from, createJobFromCronJob, createJobFromJob)
+	}
+
+	if o.from != "" && len(o.command) > 0 {
+		return fmt.Errorf("cannot specify --from and command")
+	}
+
+	if o.image != "" && o.name == "" {
+		return fmt.Errorf("NAME is required when --image is specified")
+	}
+
+	for _, env := range o.env {
+		if _, _, ok := strings.Cut(env, "="); !ok {
+			return fmt.Errorf("invalid --env value %q, must be in the form KEY=VAL", env)
+		}
+	}
+
+	if o.suspend && (o.wait || o.followLogs) {
+		return fmt.Errorf("--suspend cannot be used with --wait or --follow-logs, a suspended job never starts")
+	}
+
+	if o.timeout < 0 {
+		return fmt.Errorf("--timeout must not be negative")
+	}
+
 	return nil
 }
 
-// Run execute fizzy finder and create job from cronJob.
+// Run execute fizzy finder and create job from cronJob or job.
+// If an image is specified, create job without the fuzzy finder.
 func (o *CreateJobOptions) Run(ctx context.Context) error {
+	var (
+		job *batchv1.Job
+		err error
+	)
+
+	switch o.from {
+	case createJobFromCronJob:
+		job, err = o.jobFromCronJob(ctx)
+	case createJobFromJob:
+		job, err = o.jobFromJob(ctx)
+	default:
+		job = o.createJob()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := o.applyOverrides(job); err != nil {
+		return err
+	}
+
+	if o.edit {
+		job, err = o.editJob(job)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.dryRunStrategy == cmdutil.DryRunClient {
+		job.Namespace = o.namespace
+
+		return o.printObj(job)
+	}
+
+	options := metav1.CreateOptions{}
+	if o.dryRunStrategy == cmdutil.DryRunServer {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	res, err := o.jobClient.Jobs(o.namespace).Create(ctx, job, options)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	if err := o.printObj(res); err != nil {
+		return err
+	}
+
+	if o.dryRunStrategy != cmdutil.DryRunNone || !o.wait && !o.followLogs {
+		return nil
+	}
+
+	if res.Spec.Suspend != nil && *res.Spec.Suspend {
+		return fmt.Errorf("job.batch/%s is suspended, resume it to wait for it", res.Name)
+	}
+
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	if o.followLogs {
+		if err := o.followJobLogs(ctx, res); err != nil {
+			return err
+		}
+	}
+
+	return o.waitForJob(ctx, res)
+}
+
+// selectInfo lists the objects of the resource type and executes the fuzzy finder.
+func (o *CreateJobOptions) selectInfo(ctx context.Context, resourceType string,
+	filter func([]*resource.Info) []*resource.Info) (*resource.Info, error) {
 	infos, err := o.builder.
 		Unstructured().
 		NamespaceParam(o.namespace).DefaultNamespace().
-		ResourceTypes(o.from).
+		ResourceTypes(resourceType).
 		SelectAllParam(true).
 		Flatten().
 		Latest().
 		Do().
 		Infos()
 	if err != nil {
-		return fmt.Errorf("failed to list cronJobs: %w", err)
+		return nil, fmt.Errorf("failed to list %s: %w", resourceType, err)
+	}
+
+	if filter != nil {
+		infos = filter(infos)
 	}
 
 	if len(infos) == 0 {
-		return fmt.Errorf("resource not found")
+		return nil, fmt.Errorf("resource not found")
 	}
 
-	var printer printers.ResourcePrinter
-	if o.preview {
-		printer, err = o.previewPrintFlags.ToPrinter(o.previewFormat)
-		if err != nil {
-			return err
-		}
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
+	if err != nil {
+		return nil, err
 	}
 
-	info, err := fuzzyfinder.Infos(infos,
+	finderOpts := append(previewOpts,
 		fuzzyfinder.WithAllNamespaces(false),
-		fuzzyfinder.WithPreview(printer),
-		fuzzyfinder.WithRawPreview(o.rawPreview))
+		fuzzyfinder.WithScore(hist.score))
+
+	info, err := fuzzyfinder.Infos(infos, append(finderOpts, o.finder.options(o.ErrOut)...)...)
 	if err != nil {
-		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	hist.record(info)
+
+	return info, nil
+}
+
+// jobFromCronJob selects a CronJob with the fuzzy finder and returns a job created from its job template.
+func (o *CreateJobOptions) jobFromCronJob(ctx context.Context) (*batchv1.Job, error) {
+	info, err := o.selectInfo(ctx, createJobFromCronJob, nil)
+	if err != nil {
+		return nil, err
 	}
 
 	uncastVersionedObj, err := scheme.Scheme.ConvertToVersion(info.Object, batchv1beta1.SchemeGroupVersion)
 	if err != nil {
-		return fmt.Errorf("failed to convert resource into cronjob: %w", err)
+		return nil, fmt.Errorf("failed to convert resource into cronjob: %w", err)
 	}
 
 	cj, ok := uncastVersionedObj.(*batchv1beta1.CronJob)
 	if !ok {
-		return fmt.Errorf("failed to cast cronjob")
+		return nil, fmt.Errorf("failed to cast cronjob")
 	}
 
-	job := o.createJobFromCronJob(cj, &o.name)
+	return o.createJobFromCronJob(cj, &o.name), nil
+}
 
-	res, err := o.jobClient.Jobs(cj.Namespace).Create(context.Background(), job, metav1.CreateOptions{})
+// jobFromJob selects a finished Job with the fuzzy finder and returns a job with the same spec.
+func (o *CreateJobOptions) jobFromJob(ctx context.Context) (*batchv1.Job, error) {
+	info, err := o.selectInfo(ctx, createJobFromJob, finishedJobInfos)
 	if err != nil {
-		return fmt.Errorf("failed to create job: %w", err)
+		return nil, err
+	}
+
+	job, err := toJob(info)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.createJobFromJob(job), nil
+}
+
+// createJob returns a job running the image and the command.
+func (o *CreateJobOptions) createJob() *batchv1.Job {
+	return &batchv1.Job{
+		// this is ok because we know exactly how we want to be serialized
+		TypeMeta: metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: o.name,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:    o.name,
+							Image:   o.image,
+							Command: o.command,
+						},
+					},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
+
+// createJobFromJob returns a job with the spec of the job.
+// The selector and the labels bound to the UID of the original job are removed
+// so that the job controller generates them for the new job.
+func (o *CreateJobOptions) createJobFromJob(original *batchv1.Job) *batchv1.Job {
+	spec := original.Spec.DeepCopy()
+	spec.Selector = nil
+	spec.ManualSelector = nil
+	spec.Template.Labels = withoutControllerLabels(spec.Template.Labels)
+
+	job := &batchv1.Job{
+		// this is ok because we know exactly how we want to be serialized
+		TypeMeta: metav1.TypeMeta{APIVersion: batchv1.SchemeGroupVersion.String(), Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:       withoutControllerLabels(original.Labels),
+			GenerateName: fmt.Sprintf("%s-", original.Name),
+		},
+		Spec: *spec,
+	}
+	if o.name != "" {
+		job.Name = o.name
+	}
+
+	return job
+}
+
+func withoutControllerLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return labels
+	}
+
+	stripped := make(map[string]string, len(labels))
+	for k, v := range labels {
+		stripped[k] = v
+	}
+
+	for _, k := range controllerLabels {
+		delete(stripped, k)
+	}
+
+	return stripped
+}
+
+// finishedJobInfos returns the infos of the jobs that completed or failed.
+func finishedJobInfos(infos []*resource.Info) []*resource.Info {
+	var finished []*resource.Info
+
+	for _, info := range infos {
+		job, err := toJob(info)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := jobFinished(job); ok {
+			finished = append(finished, info)
+		}
+	}
+
+	return finished
+}
+
+func toJob(info *resource.Info) (*batchv1.Job, error) {
+	u, ok := info.Object.(runtime.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unsupported object type %T", info.Object)
+	}
+
+	var job batchv1.Job
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), &job); err != nil {
+		return nil, fmt.Errorf("failed to convert resource into job: %w", err)
 	}
 
-	return o.printObj(res)
+	return &job, nil
 }
 
 func (o *CreateJobOptions) createJobFromCronJob(cronJob *batchv1beta1.CronJob, name *string) *batchv1.Job {
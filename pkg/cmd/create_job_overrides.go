@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/kubectl/pkg/cmd/util/editor"
+	"sigs.k8s.io/yaml"
+)
+
+const editHeader = `# Please edit the job below. Lines beginning with a '#' will be ignored,
+# and an empty file will abort the creation.
+#
+`
+
+// applyOverrides applies --env, --args, --set-image and --suspend to the job template.
+func (o *CreateJobOptions) applyOverrides(job *batchv1.Job) error {
+	if o.suspend {
+		suspend := true
+		job.Spec.Suspend = &suspend
+	}
+
+	if len(o.env) == 0 && len(o.args) == 0 && o.setImage == "" {
+		return nil
+	}
+
+	container, err := jobContainer(job, o.container)
+	if err != nil {
+		return err
+	}
+
+	for _, env := range o.env {
+		key, value, _ := strings.Cut(env, "=")
+		setEnv(container, key, value)
+	}
+
+	if len(o.args) > 0 {
+		container.Args = o.args
+	}
+
+	if o.setImage != "" {
+		container.Image = o.setImage
+	}
+
+	return nil
+}
+
+// jobContainer returns the container of the job template with the name.
+// If the name is empty, the first container is returned.
+func jobContainer(job *batchv1.Job, name string) (*corev1.Container, error) {
+	containers := job.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("job template has no containers")
+	}
+
+	if name == "" {
+		return &containers[0], nil
+	}
+
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("container %q not found in the job template", name)
+}
+
+// setEnv sets the environment variable of the container, replacing the existing one with the same name.
+func setEnv(container *corev1.Container, key, value string) {
+	for i := range container.Env {
+		if container.Env[i].Name == key {
+			container.Env[i] = corev1.EnvVar{Name: key, Value: value}
+
+			return
+		}
+	}
+
+	container.Env = append(container.Env, corev1.EnvVar{Name: key, Value: value})
+}
+
+// editJob opens the job in the editor and returns the edited job.
+func (o *CreateJobOptions) editJob(job *batchv1.Job) (*batchv1.Job, error) {
+	b, err := yaml.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job: %w", err)
+	}
+
+	edit := editor.NewDefaultEditor([]string{"KUBE_EDITOR", "EDITOR"})
+
+	edited, path, err := edit.LaunchTempFile("kubectl-fuzzy-edit-", ".yaml",
+		bytes.NewReader(append([]byte(editHeader), b...)))
+	if path != "" {
+		defer os.Remove(path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch editor: %w", err)
+	}
+
+	// the YAML decoder ignores the comments
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(edited, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode edited job: %w", err)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("edit canceled, the file is empty")
+	}
+
+	var editedJob batchv1.Job
+	if err := yaml.UnmarshalStrict(edited, &editedJob); err != nil {
+		return nil, fmt.Errorf("failed to decode edited job: %w", err)
+	}
+
+	return &editedJob, nil
+}
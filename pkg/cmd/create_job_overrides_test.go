@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestJob(containers ...corev1.Container) *batchv1.Job {
+	job := &batchv1.Job{}
+	job.Spec.Template.Spec.Containers = containers
+
+	return job
+}
+
+func TestApplyOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		o         CreateJobOptions
+		want      corev1.Container
+		wantError bool
+	}{
+		{
+			name: "env replaces and appends",
+			o:    CreateJobOptions{env: []string{"A=2", "C=3=4"}},
+			want: corev1.Container{Name: "app", Image: "app:1", Env: []corev1.EnvVar{
+				{Name: "A", Value: "2"}, {Name: "B", Value: "1"}, {Name: "C", Value: "3=4"},
+			}},
+		},
+		{
+			name: "args and image of the named container",
+			o:    CreateJobOptions{container: "app", args: []string{"--once"}, setImage: "app:2"},
+			want: corev1.Container{Name: "app", Image: "app:2", Args: []string{"--once"}, Env: []corev1.EnvVar{
+				{Name: "A", Value: "1"}, {Name: "B", Value: "1"},
+			}},
+		},
+		{
+			name:      "unknown container",
+			o:         CreateJobOptions{container: "web", setImage: "app:2"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := newTestJob(corev1.Container{Name: "app", Image: "app:1", Env: []corev1.EnvVar{
+				{Name: "A", Value: "1"}, {Name: "B", Value: "1"},
+			}})
+
+			err := tt.o.applyOverrides(job)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("applyOverrides() error = %v, wantError %v", err, tt.wantError)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got := job.Spec.Template.Spec.Containers[0]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("container = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditJobKeepsHashLinesInBlockScalars(t *testing.T) {
+	edited := `# a comment removed by the decoder
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:1
+        args:
+        - |
+          # not a comment
+          echo done
+`
+
+	dir := t.TempDir()
+	content := filepath.Join(dir, "edited.yaml")
+	script := filepath.Join(dir, "editor.sh")
+
+	if err := os.WriteFile(content, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncp "+content+" \"$1\"\n"), 0o700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	t.Setenv("KUBE_EDITOR", script)
+
+	o := &CreateJobOptions{}
+
+	job, err := o.editJob(newTestJob(corev1.Container{Name: "app", Image: "app:1"}))
+	if err != nil {
+		t.Fatalf("editJob() error = %v", err)
+	}
+
+	want := []string{"# not a comment\necho done\n"}
+	if got := job.Spec.Template.Spec.Containers[0].Args; !reflect.DeepEqual(got, want) {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+func TestEditJobCanceledWithCommentsOnly(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "editor.sh")
+
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho '# only a comment' > \"$1\"\n"), 0o700); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	t.Setenv("KUBE_EDITOR", script)
+
+	o := &CreateJobOptions{}
+
+	if _, err := o.editJob(newTestJob(corev1.Container{Name: "app", Image: "app:1"})); err == nil {
+		t.Error("editJob() error = nil, want the edit canceled")
+	}
+}
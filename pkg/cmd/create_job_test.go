@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestCreateJob(t *testing.T) {
+	o := &CreateJobOptions{name: "hello", image: "busybox", command: []string{"date", "-u"}}
+
+	got := o.createJob()
+
+	want := &batchv1.Job{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+		ObjectMeta: metav1.ObjectMeta{Name: "hello"},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:    []corev1.Container{{Name: "hello", Image: "busybox", Command: []string{"date", "-u"}}},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("createJob() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateJobFromJob(t *testing.T) {
+	manual := true
+
+	original := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "migrate",
+			Namespace: "demo",
+			UID:       "0123",
+			Labels: map[string]string{
+				"app":                      "migrate",
+				"controller-uid":           "0123",
+				"job-name":                 "migrate",
+				batchv1.ControllerUidLabel: "0123",
+				batchv1.JobNameLabel:       "migrate",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			ManualSelector: &manual,
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "0123"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+					"app":                      "migrate",
+					"controller-uid":           "0123",
+					batchv1.ControllerUidLabel: "0123",
+					batchv1.JobNameLabel:       "migrate",
+				}},
+				Spec: corev1.PodSpec{
+					Containers:    []corev1.Container{{Name: "migrate", Image: "migrate:1"}},
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		jobName          string
+		wantName         string
+		wantGenerateName string
+	}{
+		{name: "generated name", wantGenerateName: "migrate-"},
+		{name: "given name", jobName: "migrate-again", wantName: "migrate-again", wantGenerateName: "migrate-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := original.DeepCopy()
+
+			o := &CreateJobOptions{name: tt.jobName}
+			got := o.createJobFromJob(original)
+
+			want := &batchv1.Job{
+				TypeMeta: metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:         tt.wantName,
+					GenerateName: tt.wantGenerateName,
+					Labels:       map[string]string{"app": "migrate"},
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "migrate"}},
+						Spec:       original.Spec.Template.Spec,
+					},
+				},
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("createJobFromJob() = %+v, want %+v", got, want)
+			}
+
+			if !reflect.DeepEqual(original, before) {
+				t.Errorf("createJobFromJob() modified the original job: %+v", original)
+			}
+		})
+	}
+}
+
+func TestFinishedJobInfos(t *testing.T) {
+	info := func(name string, conditions ...batchv1.JobCondition) *resource.Info {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		job.Status.Conditions = conditions
+
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(job)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &resource.Info{Name: name, Object: &unstructured.Unstructured{Object: u}}
+	}
+
+	infos := []*resource.Info{
+		info("complete", batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+		info("failed", batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}),
+		info("running"),
+		info("suspended", batchv1.JobCondition{Type: batchv1.JobSuspended, Status: corev1.ConditionTrue}),
+		info("not complete", batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionFalse}),
+	}
+
+	var got []string
+	for _, info := range finishedJobInfos(infos) {
+		got = append(got, info.Name)
+	}
+
+	if want := []string{"complete", "failed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("finishedJobInfos() = %v, want %v", got, want)
+	}
+}
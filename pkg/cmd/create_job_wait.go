@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const jobPollInterval = time.Second
+
+// followJobLogs streams the logs of the pods of the job one by one as soon as their container starts,
+// until the job finishes.
+func (o *CreateJobOptions) followJobLogs(ctx context.Context, job *batchv1.Job) error {
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid job selector: %w", err)
+	}
+
+	containerName := o.container
+	if containerName == "" && len(job.Spec.Template.Spec.Containers) > 0 {
+		containerName = job.Spec.Template.Spec.Containers[0].Name
+	}
+
+	followed := make(map[string]bool)
+
+	for {
+		var pod *corev1.Pod
+
+		err := wait.PollUntilContextCancel(ctx, jobPollInterval, true, func(ctx context.Context) (bool, error) {
+			pods, err := o.podClient.Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				return retryTransient(fmt.Errorf("failed to list pods: %w", err))
+			}
+
+			for i := range pods.Items {
+				p := &pods.Items[i]
+				if !followed[p.Name] && containerStarted(p, containerName) {
+					pod = p
+
+					return true, nil
+				}
+			}
+
+			current, err := o.jobClient.Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+			if err != nil {
+				return retryTransient(fmt.Errorf("failed to get job: %w", err))
+			}
+
+			_, finished := jobFinished(current)
+
+			return finished, nil
+		})
+		if err != nil {
+			return waitError(err)
+		}
+
+		if pod == nil {
+			// the job finished without any other pod to follow
+			return nil
+		}
+
+		followed[pod.Name] = true
+
+		_, _ = fmt.Fprintf(o.ErrOut, "following logs of pod/%s\n", pod.Name)
+
+		if err := o.streamJobLogs(ctx, pod, containerName); err != nil {
+			return waitError(err)
+		}
+	}
+}
+
+func (o *CreateJobOptions) streamJobLogs(ctx context.Context, pod *corev1.Pod, containerName string) error {
+	reader, err := o.podClient.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: containerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to stream logs: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	_, err = io.Copy(o.Out, reader)
+
+	return err
+}
+
+// waitForJob waits for the job to finish.
+// An error is returned if the job failed.
+func (o *CreateJobOptions) waitForJob(ctx context.Context, job *batchv1.Job) error {
+	var condition *batchv1.JobCondition
+
+	err := wait.PollUntilContextCancel(ctx, jobPollInterval, true, func(ctx context.Context) (bool, error) {
+		current, err := o.jobClient.Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			return retryTransient(fmt.Errorf("failed to get job: %w", err))
+		}
+
+		var finished bool
+		condition, finished = jobFinished(current)
+
+		return finished, nil
+	})
+	if err != nil {
+		return waitError(err)
+	}
+
+	if condition.Type == batchv1.JobFailed {
+		return fmt.Errorf("job.batch/%s failed: %s", job.Name, condition.Message)
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, "job.batch/%s complete\n", job.Name)
+
+	return nil
+}
+
+// retryTransient returns the result of the poll condition for the error.
+// The poll is continued on transient errors (e.g. a timeout or an unavailable API server)
+// and stopped on the others.
+func retryTransient(err error) (bool, error) {
+	if !transientError(err) {
+		return false, err
+	}
+
+	klog.V(1).Infof("retrying: %s", err)
+
+	return false, nil
+}
+
+// transientError reports whether the request may succeed when retried.
+func transientError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsUnexpectedServerError(err) ||
+		utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err)
+}
+
+// waitError returns the error of the poll with a readable message if the deadline of --timeout is exceeded.
+func waitError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for the job to finish")
+	}
+
+	return err
+}
+
+// jobFinished returns the Complete or Failed condition of the job if the job has finished.
+func jobFinished(job *batchv1.Job) (*batchv1.JobCondition, bool) {
+	for i := range job.Status.Conditions {
+		c := &job.Status.Conditions[i]
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return c, true
+		}
+	}
+
+	return nil, false
+}
+
+// containerStarted returns true if the container of the pod is running or has terminated.
+func containerStarted(pod *corev1.Pod, containerName string) bool {
+	status := containerStatus(pod, containerName)
+	if status == nil {
+		return false
+	}
+
+	return status.State.Running != nil || status.State.Terminated != nil
+}
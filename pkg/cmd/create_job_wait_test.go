@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWaitForJob(t *testing.T) {
+	jobResource := schema.GroupResource{Group: "batch", Resource: "jobs"}
+
+	finishedJob := func(condition batchv1.JobConditionType) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "demo"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: condition, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		results []func() (runtime.Object, error)
+		timeout time.Duration
+		wantErr string
+	}{
+		{
+			name: "retries transient errors",
+			results: []func() (runtime.Object, error){
+				func() (runtime.Object, error) { return nil, apierrors.NewServiceUnavailable("restarting") },
+				func() (runtime.Object, error) { return finishedJob(batchv1.JobComplete), nil },
+			},
+		},
+		{
+			name: "failed job",
+			results: []func() (runtime.Object, error){
+				func() (runtime.Object, error) { return finishedJob(batchv1.JobFailed), nil },
+			},
+			wantErr: "job.batch/backup failed: BackoffLimitExceeded",
+		},
+		{
+			name: "stops on permanent errors",
+			results: []func() (runtime.Object, error){
+				func() (runtime.Object, error) { return nil, apierrors.NewNotFound(jobResource, "backup") },
+			},
+			wantErr: "not found",
+		},
+		{
+			name: "timeout",
+			results: []func() (runtime.Object, error){
+				func() (runtime.Object, error) { return &batchv1.Job{}, nil },
+			},
+			timeout: 100 * time.Millisecond,
+			wantErr: "timed out waiting for the job to finish",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+
+			var calls int
+
+			client.PrependReactor("get", "jobs", func(k8stesting.Action) (bool, runtime.Object, error) {
+				result := tt.results[min(calls, len(tt.results)-1)]
+				calls++
+
+				obj, err := result()
+
+				return true, obj, err
+			})
+
+			o := &CreateJobOptions{
+				jobClient: client.BatchV1(),
+				IOStreams: genericclioptions.IOStreams{Out: io.Discard, ErrOut: io.Discard},
+			}
+
+			ctx := context.Background()
+
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			err := o.waitForJob(ctx, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "demo"}})
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("waitForJob() error = %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("waitForJob() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateJobValidateSuspend(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       CreateJobOptions
+		wantErr bool
+	}{
+		{name: "suspend", o: CreateJobOptions{suspend: true}},
+		{name: "suspend and wait", o: CreateJobOptions{suspend: true, wait: true}, wantErr: true},
+		{name: "suspend and follow logs", o: CreateJobOptions{suspend: true, followLogs: true}, wantErr: true},
+		{name: "negative timeout", o: CreateJobOptions{wait: true, timeout: -time.Second}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.o.from = createJobFromCronJob
+			tt.o.preview = previewFlags{color: colorAuto, colorTheme: "default", previewFormat: "yaml"}
+
+			if err := tt.o.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
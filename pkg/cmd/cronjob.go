@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	exampleCronJob = `
+	# Selecting a CronJob with the fuzzy finder and suspend it
+	kubectl fuzzy cronjob suspend [flags]
+
+	# Selecting a CronJob with the fuzzy finder and resume it
+	kubectl fuzzy cronjob resume [flags]
+
+	# Selecting a CronJob with the fuzzy finder and print the next 10 run times
+	kubectl fuzzy cronjob next --count=10 [flags]
+`
+
+	cronJobSuspend = "suspend"
+	cronJobResume  = "resume"
+	cronJobNext    = "next"
+
+	defaultNextCount = 5
+)
+
+// NewCmdCronJob provides a cobra command managing CronJobs.
+func NewCmdCronJob(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "cronjob",
+		Short:                 "Selecting a CronJob with the fuzzy finder and suspend, resume or inspect its schedule",
+		Example:               exampleCronJob,
+		SilenceUsage:          true,
+		SilenceErrors:         true,
+		DisableFlagsInUseLine: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return fmt.Errorf("must specify subcommand, one of %s|%s|%s", cronJobSuspend, cronJobResume, cronJobNext)
+		},
+	}
+
+	cmd.AddCommand(newCmdCronJobAction(config, streams, cronJobSuspend,
+		"Selecting a CronJob with the fuzzy finder and suspend it"))
+	cmd.AddCommand(newCmdCronJobAction(config, streams, cronJobResume,
+		"Selecting a CronJob with the fuzzy finder and resume it"))
+	cmd.AddCommand(newCmdCronJobAction(config, streams, cronJobNext,
+		"Selecting a CronJob with the fuzzy finder and print the upcoming run times"))
+
+	return cmd
+}
+
+func newCmdCronJobAction(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams,
+	action, short string) *cobra.Command {
+	o := NewCronJobOptions(config, streams, action)
+
+	cmd := &cobra.Command{
+		Use:           action,
+		Short:         short,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			return o.Run(c.Context())
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// CronJobOptions provides information required to suspend, resume or inspect the schedule of CronJobs.
+type CronJobOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	printFlags  *genericclioptions.JSONYamlPrintFlags
+	genericclioptions.IOStreams
+
+	action string
+	count  int
+
+	allNamespaces bool
+	namespace     string
+	selector      string
+
+	finder  finderFlags
+	preview previewFlags
+}
+
+// NewCronJobOptions provides an instance of CronJobOptions with default values.
+func NewCronJobOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams,
+	action string) *CronJobOptions {
+	return &CronJobOptions{
+		configFlags: config,
+		printFlags:  genericclioptions.NewJSONYamlPrintFlags(),
+		IOStreams:   streams,
+		action:      action,
+	}
+}
+
+// AddFlags adds a flag to the flag set.
+func (o *CronJobOptions) AddFlags(flags *pflag.FlagSet) {
+	// kubectl flags
+	flags.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false,
+		"If present, list the requested object(s) across all namespaces. "+
+			"Namespace in current context is ignored even if specified with --namespace.")
+	flags.StringVarP(&o.selector, "selector", "l", "",
+		"Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+
+	// original flags
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
+
+	if o.action == cronJobNext {
+		flags.IntVar(&o.count, "count", defaultNextCount, "Number of upcoming run times to print.")
+	}
+}
+
+// Complete sets all information required for managing CronJobs.
+func (o *CronJobOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.preview.Complete()
+
+	if !o.allNamespaces {
+		namespace, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return fmt.Errorf("faild to get namespace from kube config: %w", err)
+		}
+
+		o.namespace = namespace
+	}
+
+	return nil
+}
+
+// Validate ensures that all required arguments and flag values are provided.
+func (o *CronJobOptions) Validate() error {
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.finder.Validate(); err != nil {
+		return err
+	}
+
+	if o.action == cronJobNext && o.count < 1 {
+		return fmt.Errorf("--count must be greater than 0")
+	}
+
+	return nil
+}
+
+// Run execute fizzy finder and suspend, resume or print the upcoming run times of the selected CronJob.
+func (o *CronJobOptions) Run(ctx context.Context) error {
+	info, cronJob, err := o.selectCronJob(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch o.action {
+	case cronJobSuspend:
+		return o.setSuspend(info, true)
+	case cronJobResume:
+		return o.setSuspend(info, false)
+	case cronJobNext:
+		times, err := nextSchedules(cronJob, time.Now(), o.count)
+		if err != nil {
+			return err
+		}
+
+		if cronJob.Spec.TimeZone == nil {
+			_, _ = fmt.Fprintln(o.ErrOut, "spec.timeZone is not set, assuming UTC "+
+				"(the CronJob controller uses the time zone of kube-controller-manager, which is usually UTC)")
+		}
+
+		for _, t := range times {
+			_, _ = fmt.Fprintln(o.Out, t.Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+func (o *CronJobOptions) selectCronJob(ctx context.Context) (*resource.Info, *batchv1.CronJob, error) {
+	infos, err := resource.NewBuilder(o.configFlags).
+		Unstructured().
+		NamespaceParam(o.namespace).DefaultNamespace().AllNamespaces(o.allNamespaces).
+		LabelSelectorParam(o.selector).
+		ResourceTypes("cronjobs").
+		SelectAllParam(o.selector == "").
+		Flatten().
+		Do().
+		Infos()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list cronJobs: %w", err)
+	}
+
+	if len(infos) == 0 {
+		return nil, nil, fmt.Errorf("resource not found")
+	}
+
+	cronJobs := make(map[*resource.Info]*batchv1.CronJob, len(infos))
+
+	for _, info := range infos {
+		cronJob, err := toCronJob(info)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		cronJobs[info] = cronJob
+	}
+
+	lines := cronJobLines(infos, cronJobs, o.allNamespaces)
+
+	o.preview.defaultLine = func(info *resource.Info) string {
+		return lines[info]
+	}
+
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finderOpts := append(previewOpts,
+		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
+		fuzzyfinder.WithScore(hist.score))
+
+	info, err := fuzzyfinder.Infos(infos, append(finderOpts, o.finder.options(o.ErrOut)...)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	hist.record(info)
+
+	return info, cronJobs[info], nil
+}
+
+// setSuspend sets spec.suspend of the CronJob.
+func (o *CronJobOptions) setSuspend(info *resource.Info, suspend bool) error {
+	patch := fmt.Sprintf(`{"spec":{"suspend":%t}}`, suspend)
+
+	obj, err := resource.NewHelper(info.Client, info.Mapping).
+		Patch(info.Namespace, info.Name, types.MergePatchType, []byte(patch), nil)
+	if err != nil {
+		return fmt.Errorf("failed to patch cronjob: %w", err)
+	}
+
+	if err := info.Refresh(obj, true); err != nil {
+		return err
+	}
+
+	operation := "resumed"
+	if suspend {
+		operation = "suspended"
+	}
+
+	_, _ = fmt.Fprintf(o.Out, "cronjob.batch/%s %s\n", info.Name, operation)
+
+	return nil
+}
+
+// cronJobLines returns the candidate lines of the CronJobs
+// showing the schedule, the suspend state, the last schedule time and the number of active jobs.
+func cronJobLines(infos []*resource.Info, cronJobs map[*resource.Info]*batchv1.CronJob,
+	allNamespaces bool) map[*resource.Info]string {
+	var buf bytes.Buffer
+
+	w := printers.GetNewTabWriter(&buf)
+
+	for _, info := range infos {
+		cronJob := cronJobs[info]
+
+		name := info.Name
+		if allNamespaces {
+			name = fmt.Sprintf("%s (%s)", info.Name, info.Namespace)
+		}
+
+		suspend := cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+
+		lastSchedule := "<none>"
+		if cronJob.Status.LastScheduleTime != nil {
+			lastSchedule = duration.HumanDuration(time.Since(cronJob.Status.LastScheduleTime.Time)) + " ago"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\tsuspend=%t\tlast=%s\tactive=%d\n",
+			name, cronJob.Spec.Schedule, suspend, lastSchedule, len(cronJob.Status.Active))
+	}
+
+	_ = w.Flush()
+
+	lines := make(map[*resource.Info]string, len(infos))
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		lines[infos[i]] = line
+	}
+
+	return lines
+}
+
+// nextSchedules returns the next count run times of the CronJob after the time
+// in the time zone of the CronJob, or in UTC if the time zone is not set.
+func nextSchedules(cronJob *batchv1.CronJob, after time.Time, count int) ([]time.Time, error) {
+	location := time.UTC
+
+	if cronJob.Spec.TimeZone != nil {
+		var err error
+
+		location, err = time.LoadLocation(*cronJob.Spec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", *cronJob.Spec.TimeZone, err)
+		}
+	}
+
+	schedule, err := cron.ParseStandard(cronJob.Spec.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", cronJob.Spec.Schedule, err)
+	}
+
+	times := make([]time.Time, 0, count)
+
+	t := after.In(location)
+	for i := 0; i < count; i++ {
+		t = schedule.Next(t)
+		if t.IsZero() {
+			break
+		}
+
+		times = append(times, t)
+	}
+
+	return times, nil
+}
+
+func toCronJob(info *resource.Info) (*batchv1.CronJob, error) {
+	u, ok := info.Object.(runtime.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unsupported object type %T", info.Object)
+	}
+
+	var cronJob batchv1.CronJob
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), &cronJob); err != nil {
+		return nil, fmt.Errorf("failed to convert resource into cronjob: %w", err)
+	}
+
+	return &cronJob, nil
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+func TestNextSchedules(t *testing.T) {
+	after := time.Date(2024, 3, 9, 22, 30, 0, 0, time.UTC)
+	tokyo := "Asia/Tokyo"
+	invalid := "Mars/Olympus"
+
+	tests := []struct {
+		name     string
+		schedule string
+		timeZone *string
+		count    int
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "utc by default",
+			schedule: "0 0 * * *",
+			count:    2,
+			want:     []string{"2024-03-10T00:00:00Z", "2024-03-11T00:00:00Z"},
+		},
+		{
+			name:     "time zone of the cronjob",
+			schedule: "0 9 * * *",
+			timeZone: &tokyo,
+			count:    1,
+			want:     []string{"2024-03-10T09:00:00+09:00"},
+		},
+		{
+			name:     "macro",
+			schedule: "@hourly",
+			count:    2,
+			want:     []string{"2024-03-09T23:00:00Z", "2024-03-10T00:00:00Z"},
+		},
+		{name: "invalid schedule", schedule: "every day", count: 1, wantErr: true},
+		{name: "invalid time zone", schedule: "0 0 * * *", timeZone: &invalid, count: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cronJob := &batchv1.CronJob{Spec: batchv1.CronJobSpec{Schedule: tt.schedule, TimeZone: tt.timeZone}}
+
+			times, err := nextSchedules(cronJob, after, tt.count)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextSchedules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, t := range times {
+				got = append(got, t.Format(time.RFC3339))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nextSchedules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,23 +1,30 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"strconv"
+	"io"
 	"strings"
 	"time"
 
+	"github.com/d-kuro/kubectl-fuzzy/pkg/backup"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/config"
 	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -28,6 +35,18 @@ const (
 	exampleDelete = `
 	# Selecting an object with the fuzzy finder and delete
 	kubectl fuzzy delete TYPE [flags]
+
+	# Delete without confirmation
+	kubectl fuzzy delete TYPE --yes [flags]
+
+	# Save the object to the backup directory before deleting
+	kubectl fuzzy delete TYPE --backup [flags]
+
+	# Selecting the resource type with the fuzzy finder when TYPE is omitted
+	kubectl fuzzy delete [flags]
+
+	# Select the namespace, the resource type and then the object level by level
+	kubectl fuzzy delete --drill-down [flags]
 `
 )
 
@@ -65,7 +84,6 @@ func NewCmdDelete(config *genericclioptions.ConfigFlags, streams genericclioptio
 // the current context on a user's KUBECONFIG.
 type DeleteOptions struct {
 	configFlags *genericclioptions.ConfigFlags
-	printFlags  *genericclioptions.JSONYamlPrintFlags
 	genericclioptions.IOStreams
 
 	allNamespaces    bool
@@ -84,12 +102,24 @@ type DeleteOptions struct {
 
 	output string
 
-	dynamicClient dynamic.Interface
-	namespace     string
+	dynamicClient   dynamic.Interface
+	discoveryClient discovery.DiscoveryInterface
+	namespace       string
+
+	yes          bool
+	noDependents bool
+	protection   config.Protection
+
+	backup    bool
+	backupDir string
 
-	preview       bool
-	previewFormat string
-	rawPreview    bool
+	ownerGraph *kubernetes.OwnerGraph
+
+	stripFinalizers bool
+
+	finder    finderFlags
+	preview   previewFlags
+	drillDown bool
 }
 
 // AddFlags adds a flag to the flag set.
@@ -123,19 +153,34 @@ func (o *DeleteOptions) AddFlags(flags *pflag.FlagSet) {
 		"If true, wait for resources to be gone before returning. This waits for finalizers.")
 
 	// original flags
-	flags.BoolVarP(&o.preview, "preview", "P", false,
-		"If true, display the object YAML|JSON by preview window for fuzzy finder selector.")
-	flags.StringVar(&o.previewFormat, "preview-format", "yaml",
-		"Preview window output format. One of json|yaml.")
-	flags.BoolVar(&o.rawPreview, "raw-preview", false,
-		"If true, display the unsimplified object in the preview window. (default is simplified)")
+	flags.BoolVar(&o.drillDown, "drill-down", false,
+		"If true, select the namespace, the resource type if TYPE is omitted, and then the object "+
+			"with the fuzzy finder level by level. Esc returns to the previous level. "+
+			"The namespace level shows the number of objects in each namespace only if TYPE is given, "+
+			"and is skipped if --namespace is given without --all-namespaces.")
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
+	flags.BoolVarP(&o.yes, "yes", "y", false,
+		"If true, delete the selected object without confirmation.")
+	flags.BoolVar(&o.noDependents, "no-dependents", false,
+		"If true, do not show the number of dependents of the object in the confirmation. "+
+			"Counting lists all namespaced resource types in the namespace of the object, "+
+			"or in all namespaces for a cluster-scoped object.")
+	flags.BoolVar(&o.backup, "backup", false,
+		"If true, save the object (and its dependents when --cascade is true) to --backup-dir before deleting. "+
+			"The backup can be restored with \"kubectl fuzzy restore\".")
+	flags.StringVar(&o.backupDir, "backup-dir", backup.DefaultDir(),
+		"Directory where backups are saved.")
+	flags.BoolVar(&o.stripFinalizers, "strip-finalizers", false,
+		"If true, remove all finalizers of the object after requesting the deletion "+
+			"so that the object is not stuck in Terminating. This skips the cleanup performed by the finalizers.")
 }
 
 // NewDeleteOptions provides an instance of DeleteOptions with default values.
 func NewDeleteOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *DeleteOptions {
 	return &DeleteOptions{
 		configFlags: config,
-		printFlags:  genericclioptions.NewJSONYamlPrintFlags(),
+		preview:     previewFlags{objectFormats: true},
 		IOStreams:   streams,
 	}
 }
@@ -147,9 +192,7 @@ func (o *DeleteOptions) Complete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("faild to get namespace from kube config: %w", err)
 	}
 
-	if !o.preview {
-		o.preview, _ = strconv.ParseBool(os.Getenv(previewEnabledEnvVar))
-	}
+	o.preview.Complete()
 
 	o.warnClusterScope = enforceNamespace && !o.allNamespaces
 
@@ -186,14 +229,34 @@ func (o *DeleteOptions) Complete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("faild to create dynamic client: %w", err)
 	}
 
+	discoveryClient, err := o.configFlags.ToDiscoveryClient()
+	if err != nil {
+		return fmt.Errorf("faild to create discovery client: %w", err)
+	}
+
+	c, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
 	o.dynamicClient = dynamicClient
+	o.discoveryClient = discoveryClient
 	o.namespace = cmdNamespace
+	o.protection = c.Delete.Protection
 
 	return nil
 }
 
 // Validate ensures that all required arguments and flag values are provided.
 func (o *DeleteOptions) Validate() error {
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.finder.Validate(); err != nil {
+		return err
+	}
+
 	switch {
 	case o.gracePeriod == 0 && o.forceDeletion:
 		_, _ = fmt.Fprintln(o.ErrOut,
@@ -208,46 +271,28 @@ func (o *DeleteOptions) Validate() error {
 
 // Run execute fizzy finder and delete object.
 func (o *DeleteOptions) Run(ctx context.Context, args []string) error {
-	r := resource.NewBuilder(o.configFlags).
-		Unstructured().
-		ContinueOnError().
-		NamespaceParam(o.namespace).DefaultNamespace().
-		LabelSelectorParam(o.labelSelector).
-		FieldSelectorParam(o.fieldSelector).
-		AllNamespaces(o.allNamespaces).
-		ResourceTypeOrNameArgs(true, args...).RequireObject(false).
-		Flatten().
-		Do()
-
-	if err := r.Err(); err != nil {
-		return fmt.Errorf("failed to request: %w", err)
-	}
-
-	infos, err := r.Infos()
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
 	if err != nil {
-		return fmt.Errorf("failed to get infos: %w", err)
-	}
-
-	if len(infos) == 0 {
-		return fmt.Errorf("resource not found")
+		return err
 	}
 
-	var printer printers.ResourcePrinter
-	if o.preview {
-		printer, err = o.printFlags.ToPrinter(o.previewFormat)
-		if err != nil {
-			return fmt.Errorf("failed to get printer: %w", err)
-		}
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
+	if err != nil {
+		return err
 	}
 
-	info, err := fuzzyfinder.Infos(infos,
+	finderOpts := append(previewOpts,
 		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
-		fuzzyfinder.WithPreview(printer),
-		fuzzyfinder.WithRawPreview(o.rawPreview))
+		fuzzyfinder.WithScore(hist.score))
+	finderOpts = append(finderOpts, o.finder.options(o.ErrOut)...)
+
+	info, err := o.selectInfo(args, finderOpts)
 	if err != nil {
-		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		return err
 	}
 
+	hist.record(info)
+
 	uidMap := cmdwait.UIDMap{}
 
 	options := &metav1.DeleteOptions{}
@@ -273,15 +318,41 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error {
 		return nil
 	}
 
+	if !o.yes && o.dryRunStrategy == cmdutil.DryRunNone {
+		confirmed, err := o.confirm(ctx, info)
+		if err != nil {
+			return err
+		}
+
+		if !confirmed {
+			return fmt.Errorf("deletion canceled")
+		}
+	}
+
 	if o.dryRunStrategy == cmdutil.DryRunServer {
 		options.DryRun = []string{metav1.DryRunAll}
 	}
 
+	if o.backup && o.dryRunStrategy == cmdutil.DryRunNone {
+		path, err := o.backupObject(ctx, info)
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(o.ErrOut, "saved backup to %s\n", path)
+	}
+
 	response, err := o.deleteResource(info, options)
 	if err != nil {
 		return err
 	}
 
+	if o.stripFinalizers && o.dryRunStrategy == cmdutil.DryRunNone {
+		if err := o.stripObjectFinalizers(info); err != nil {
+			return err
+		}
+	}
+
 	resourceLocation := cmdwait.ResourceLocation{
 		GroupResource: info.Mapping.Resource.GroupResource(),
 		Namespace:     info.Namespace,
@@ -340,7 +411,7 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error {
 	}
 
 	err = waitOptions.RunWait()
-	if errors.IsForbidden(err) || errors.IsMethodNotSupported(err) {
+	if apierrors.IsForbidden(err) || apierrors.IsMethodNotSupported(err) {
 		// if we're forbidden from waiting, we shouldn't fail.
 		// if the resource doesn't support a verb we need, we shouldn't fail.
 		klog.V(1).Info(err)
@@ -351,6 +422,172 @@ func (o *DeleteOptions) Run(ctx context.Context, args []string) error {
 	return err
 }
 
+// confirm shows the object to be deleted and asks the user for confirmation.
+// Protected objects require typing the name of the object, others require answering "y".
+func (o *DeleteOptions) confirm(ctx context.Context, info *resource.Info) (bool, error) {
+	gk := info.Mapping.GroupVersionKind.GroupKind()
+
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return false, fmt.Errorf("failed to get object metadata: %w", err)
+	}
+
+	reason, protected, err := o.protection.Protected(info.Namespace, gk, accessor.GetLabels())
+	if err != nil {
+		return false, err
+	}
+
+	contextName, clusterName, err := kubernetes.CurrentContext(o.configFlags)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current context: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, "The following object will be deleted:\n\n")
+
+	w := printers.GetNewTabWriter(o.ErrOut)
+	_, _ = fmt.Fprintf(w, "  Kind:\t%s\n", gk)
+
+	if info.Namespaced() {
+		_, _ = fmt.Fprintf(w, "  Namespace:\t%s\n", info.Namespace)
+	}
+
+	_, _ = fmt.Fprintf(w, "  Name:\t%s\n", info.Name)
+	_, _ = fmt.Fprintf(w, "  Context:\t%s (cluster: %s)\n", contextName, clusterName)
+
+	if !o.noDependents {
+		_, _ = fmt.Fprintf(w, "  Dependents:\t%s\n", o.dependentsSummary(ctx, info.Namespace, accessor.GetUID()))
+	}
+
+	_ = w.Flush()
+	_, _ = fmt.Fprintln(o.ErrOut)
+
+	reader := bufio.NewReader(o.In)
+
+	if protected {
+		_, _ = fmt.Fprintf(o.ErrOut, "This object is protected: %s.\n", reason)
+		_, _ = fmt.Fprintf(o.ErrOut, "Type the name of the object to confirm: ")
+
+		answer, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return false, fmt.Errorf("failed to read confirmation: %w", err)
+		}
+
+		return strings.TrimSpace(answer) == info.Name, nil
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, "Do you want to delete it? [y/N]: ")
+
+	answer, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// dependentsSummary returns the number of the dependents of the object with the UID
+// and whether they are deleted by cascade or orphaned, or "unknown" if they cannot be listed.
+func (o *DeleteOptions) dependentsSummary(ctx context.Context, namespace string, uid types.UID) string {
+	graph, err := o.graph(ctx, namespace)
+	if err != nil {
+		klog.V(1).Info(err)
+
+		return "unknown"
+	}
+
+	cascade := "deleted by cascade"
+	if !o.cascade {
+		cascade = "orphaned"
+	}
+
+	return fmt.Sprintf("%d (%s)", len(graph.Dependents(uid)), cascade)
+}
+
+// graph returns the owner graph of the namespace, listed once and shared by the confirmation and the backup.
+// Dependents of cluster-scoped objects are searched across all namespaces.
+func (o *DeleteOptions) graph(ctx context.Context, namespace string) (*kubernetes.OwnerGraph, error) {
+	if o.ownerGraph != nil {
+		return o.ownerGraph, nil
+	}
+
+	graph, err := kubernetes.NewOwnerGraph(ctx, o.discoveryClient, o.dynamicClient, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependents: %w", err)
+	}
+
+	o.ownerGraph = graph
+
+	return graph, nil
+}
+
+// backupObject saves the object and its dependents to be deleted by cascade to the backup directory.
+func (o *DeleteOptions) backupObject(ctx context.Context, info *resource.Info) (string, error) {
+	obj, ok := info.Object.(*unstructured.Unstructured)
+	if !ok {
+		return "", fmt.Errorf("failed to backup: unexpected object type %T", info.Object)
+	}
+
+	var dependents []*unstructured.Unstructured
+
+	if o.cascade {
+		graph, err := o.graph(ctx, info.Namespace)
+		if err != nil {
+			return "", err
+		}
+
+		dependents = graph.Dependents(obj.GetUID())
+	}
+
+	contextName, _, err := kubernetes.CurrentContext(o.configFlags)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current context: %w", err)
+	}
+
+	path, err := backup.Write(o.backupDir, contextName, obj, dependents)
+	if err != nil {
+		return "", fmt.Errorf("failed to backup: %w", err)
+	}
+
+	return path, nil
+}
+
+// stripObjectFinalizers removes all finalizers of the object being deleted.
+func (o *DeleteOptions) stripObjectFinalizers(info *resource.Info) error {
+	if err := info.Get(); err != nil {
+		if apierrors.IsNotFound(err) {
+			// already deleted
+			return nil
+		}
+
+		return fmt.Errorf("failed to get object: %w", err)
+	}
+
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return fmt.Errorf("failed to get object metadata: %w", err)
+	}
+
+	finalizers := accessor.GetFinalizers()
+	if len(finalizers) == 0 {
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(o.ErrOut, finalizerWarning)
+
+	if err := removeFinalizers(info, finalizers); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, "removed finalizers: %s\n", strings.Join(finalizers, ", "))
+
+	return nil
+}
+
 func (o *DeleteOptions) deleteResource(info *resource.Info, options *metav1.DeleteOptions) (runtime.Object, error) {
 	deleteResponse, err := resource.
 		NewHelper(info.Client, info.Mapping).
@@ -399,3 +636,71 @@ func (o *DeleteOptions) PrintObj(info *resource.Info) {
 	// understandable output by default
 	_, _ = fmt.Fprintf(o.Out, "%s \"%s\" %s\n", kindString, info.Name, operation)
 }
+
+// selectInfo selects the object to delete with the fuzzy finder.
+func (o *DeleteOptions) selectInfo(args []string, finderOpts []fuzzyfinder.Option) (*resource.Info, error) {
+	if o.drillDown {
+		d := &drillDown{
+			configFlags: o.configFlags,
+			namespace:   drillDownNamespace(o.configFlags, o.allNamespaces),
+			finderOpts:  o.finder.levelOptions(o.ErrOut),
+			verbs:       []string{"list", "delete"},
+			builder: func() *resource.Builder {
+				return resource.NewBuilder(o.configFlags).
+					Unstructured().
+					ContinueOnError().
+					LabelSelectorParam(o.labelSelector).
+					FieldSelectorParam(o.fieldSelector).
+					RequireObject(false).
+					Flatten()
+			},
+			selectObject: func(infos []*resource.Info, header string) (*resource.Info, error) {
+				return fuzzyfinder.Infos(infos, append(finderOpts,
+					fuzzyfinder.WithAllNamespaces(false),
+					fuzzyfinder.WithHeader(header))...)
+			},
+		}
+
+		return d.Run(args)
+	}
+
+	if len(args) == 0 {
+		resourceType, err := selectResourceType(o.configFlags, o.finder.levelOptions(o.ErrOut), "list", "delete")
+		if err != nil {
+			return nil, err
+		}
+
+		args = []string{resourceType}
+	}
+
+	r := resource.NewBuilder(o.configFlags).
+		Unstructured().
+		ContinueOnError().
+		NamespaceParam(o.namespace).DefaultNamespace().
+		LabelSelectorParam(o.labelSelector).
+		FieldSelectorParam(o.fieldSelector).
+		AllNamespaces(o.allNamespaces).
+		ResourceTypeOrNameArgs(true, args...).RequireObject(false).
+		Flatten().
+		Do()
+
+	if err := r.Err(); err != nil {
+		return nil, fmt.Errorf("failed to request: %w", err)
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get infos: %w", err)
+	}
+
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("resource not found")
+	}
+
+	info, err := fuzzyfinder.Infos(infos, finderOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	return info, nil
+}
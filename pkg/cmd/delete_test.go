@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// namespacedDiscovery serves the namespaced resources, which the fake discovery client does not.
+type namespacedDiscovery struct {
+	*fakediscovery.FakeDiscovery
+	err error
+}
+
+func (d *namespacedDiscovery) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return d.Resources, nil
+}
+
+func TestDependentsSummary(t *testing.T) {
+	owned := func(kind, name string, uid, owner types.UID) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace("demo")
+		obj.SetName(name)
+		obj.SetUID(uid)
+
+		if owner != "" {
+			obj.SetOwnerReferences([]metav1.OwnerReference{{UID: owner, Name: "owner"}})
+		}
+
+		return obj
+	}
+
+	objects := []runtime.Object{
+		owned("ReplicationController", "web", "rc", "deploy"),
+		owned("Pod", "web-1", "pod-1", "rc"),
+		owned("Pod", "web-2", "pod-2", "rc"),
+		owned("Pod", "standalone", "pod-3", ""),
+	}
+
+	resources := []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"list"}},
+			{Name: "replicationcontrollers", Kind: "ReplicationController", Namespaced: true, Verbs: []string{"list"}},
+		},
+	}}
+
+	listKinds := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "pods"}:                   "PodList",
+		{Version: "v1", Resource: "replicationcontrollers"}: "ReplicationControllerList",
+	}
+
+	tests := []struct {
+		name         string
+		uid          types.UID
+		cascade      bool
+		discoveryErr error
+		want         string
+	}{
+		{name: "dependents deleted by cascade", uid: "deploy", cascade: true, want: "3 (deleted by cascade)"},
+		{name: "dependents orphaned", uid: "deploy", want: "3 (orphaned)"},
+		{name: "direct children", uid: "rc", cascade: true, want: "2 (deleted by cascade)"},
+		{name: "no dependents", uid: "pod-3", cascade: true, want: "0 (deleted by cascade)"},
+		{name: "discovery failure", uid: "deploy", discoveryErr: errors.New("unavailable"), want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &DeleteOptions{
+				cascade: tt.cascade,
+				discoveryClient: &namespacedDiscovery{
+					FakeDiscovery: &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: resources}},
+					err:           tt.discoveryErr,
+				},
+				dynamicClient: dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+					objects...),
+			}
+
+			if got := o.dependentsSummary(context.Background(), "demo", tt.uid); got != tt.want {
+				t.Errorf("dependentsSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
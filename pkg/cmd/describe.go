@@ -3,15 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"strconv"
 
 	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/kubectl/pkg/describe"
 )
@@ -20,6 +17,12 @@ const (
 	exampleDescribe = `
 	# Selecting an object with the fuzzy finder and view the log and show details
 	kubectl fuzzy describe TYPE [flags]
+
+	# Selecting the resource type with the fuzzy finder when TYPE is omitted
+	kubectl fuzzy describe [flags]
+
+	# Select the namespace, the resource type and then the object level by level
+	kubectl fuzzy describe --drill-down [flags]
 `
 )
 
@@ -55,7 +58,6 @@ func NewCmdDescribe(config *genericclioptions.ConfigFlags, streams genericcliopt
 // the current context on a user's KUBECONFIG.
 type DescribeOptions struct {
 	configFlags *genericclioptions.ConfigFlags
-	printFlags  *genericclioptions.JSONYamlPrintFlags
 	genericclioptions.IOStreams
 
 	describerSettings *describe.DescriberSettings
@@ -68,9 +70,9 @@ type DescribeOptions struct {
 	selector      string
 	builderArgs   []string
 
-	preview       bool
-	previewFormat string
-	rawPreview    bool
+	finder    finderFlags
+	preview   previewFlags
+	drillDown bool
 }
 
 // AddFlags adds a flag to the flag set.
@@ -85,20 +87,21 @@ func (o *DescribeOptions) AddFlags(flags *pflag.FlagSet) {
 		"If true, display events related to the described object.")
 
 	// original flags
-	flags.BoolVarP(&o.preview, "preview", "P", false,
-		"If true, display the object YAML|JSON by preview window for fuzzy finder selector.")
-	flags.StringVar(&o.previewFormat, "preview-format", "yaml",
-		"Preview window output format. One of json|yaml.")
-	flags.BoolVar(&o.rawPreview, "raw-preview", false,
-		"If true, display the unsimplified object in the preview window. (default is simplified)")
+	flags.BoolVar(&o.drillDown, "drill-down", false,
+		"If true, select the namespace, the resource type if TYPE is omitted, and then the object "+
+			"with the fuzzy finder level by level. Esc returns to the previous level. "+
+			"The namespace level shows the number of objects in each namespace only if TYPE is given, "+
+			"and is skipped if --namespace is given without --all-namespaces.")
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
 }
 
 // NewDescribeOptions provides an instance of DescribeOptions with default values.
 func NewDescribeOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *DescribeOptions {
 	return &DescribeOptions{
 		configFlags: config,
-		printFlags:  genericclioptions.NewJSONYamlPrintFlags(),
 		IOStreams:   streams,
+		preview:     previewFlags{objectFormats: true},
 		describerSettings: &describe.DescriberSettings{
 			ShowEvents: true,
 		},
@@ -115,9 +118,7 @@ func (o *DescribeOptions) Complete(cmd *cobra.Command, args []string) error {
 
 	o.builderArgs = args
 
-	if !o.preview {
-		o.preview, _ = strconv.ParseBool(os.Getenv(previewEnabledEnvVar))
-	}
+	o.preview.Complete()
 
 	if !o.allNamespaces {
 		kubeConfig := o.configFlags.ToRawKubeConfigLoader()
@@ -134,50 +135,38 @@ func (o *DescribeOptions) Complete(cmd *cobra.Command, args []string) error {
 }
 
 // Validate ensures that all required arguments and flag values are provided.
-func (DescribeOptions) Validate() error {
-	return nil
+func (o *DescribeOptions) Validate() error {
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	return o.finder.Validate()
 }
 
 // Run execute fizzy finder and show details.
 func (o *DescribeOptions) Run(ctx context.Context, args []string) error {
-	r := o.builder.
-		Unstructured().
-		ContinueOnError().
-		NamespaceParam(o.namespace).DefaultNamespace().AllNamespaces(o.allNamespaces).
-		LabelSelectorParam(o.selector).
-		ResourceTypeOrNameArgs(true, o.builderArgs...).
-		Flatten().
-		Do()
-
-	if err := r.Err(); err != nil {
-		return fmt.Errorf("failed to request: %w", err)
-	}
-
-	infos, err := r.Infos()
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
 	if err != nil {
-		return fmt.Errorf("failed to get infos: %w", err)
+		return err
 	}
 
-	if len(infos) == 0 {
-		return fmt.Errorf("resource not found")
-	}
-
-	var printer printers.ResourcePrinter
-	if o.preview {
-		printer, err = o.printFlags.ToPrinter(o.previewFormat)
-		if err != nil {
-			return fmt.Errorf("failed to get printer: %w", err)
-		}
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
+	if err != nil {
+		return err
 	}
 
-	info, err := fuzzyfinder.Infos(infos,
+	finderOpts := append(previewOpts,
 		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
-		fuzzyfinder.WithPreview(printer),
-		fuzzyfinder.WithRawPreview(o.rawPreview))
+		fuzzyfinder.WithScore(hist.score))
+	finderOpts = append(finderOpts, o.finder.options(o.ErrOut)...)
+
+	info, err := o.selectInfo(o.builderArgs, finderOpts)
 	if err != nil {
-		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		return err
 	}
 
+	hist.record(info)
+
 	mapping := info.ResourceMapping()
 
 	describer, err := o.describer(mapping)
@@ -194,3 +183,67 @@ func (o *DescribeOptions) Run(ctx context.Context, args []string) error {
 
 	return nil
 }
+
+// selectInfo selects the object to describe with the fuzzy finder.
+func (o *DescribeOptions) selectInfo(args []string, finderOpts []fuzzyfinder.Option) (*resource.Info, error) {
+	if o.drillDown {
+		d := &drillDown{
+			configFlags: o.configFlags,
+			namespace:   drillDownNamespace(o.configFlags, o.allNamespaces),
+			finderOpts:  o.finder.levelOptions(o.ErrOut),
+			verbs:       []string{"get", "list"},
+			builder: func() *resource.Builder {
+				return resource.NewBuilder(o.configFlags).
+					Unstructured().
+					ContinueOnError().
+					LabelSelectorParam(o.selector).
+					Flatten()
+			},
+			selectObject: func(infos []*resource.Info, header string) (*resource.Info, error) {
+				return fuzzyfinder.Infos(infos, append(finderOpts,
+					fuzzyfinder.WithAllNamespaces(false),
+					fuzzyfinder.WithHeader(header))...)
+			},
+		}
+
+		return d.Run(args)
+	}
+
+	if len(args) == 0 {
+		resourceType, err := selectResourceType(o.configFlags, o.finder.levelOptions(o.ErrOut), "get", "list")
+		if err != nil {
+			return nil, err
+		}
+
+		args = []string{resourceType}
+	}
+
+	r := o.builder.
+		Unstructured().
+		ContinueOnError().
+		NamespaceParam(o.namespace).DefaultNamespace().AllNamespaces(o.allNamespaces).
+		LabelSelectorParam(o.selector).
+		ResourceTypeOrNameArgs(true, args...).
+		Flatten().
+		Do()
+
+	if err := r.Err(); err != nil {
+		return nil, fmt.Errorf("failed to request: %w", err)
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get infos: %w", err)
+	}
+
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("resource not found")
+	}
+
+	info, err := fuzzyfinder.Infos(infos, finderOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	return info, nil
+}
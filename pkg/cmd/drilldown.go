@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// drillDown selects an object level by level with the fuzzy finder:
+// the namespace, the resource type if no resource types are specified, then the object.
+// Esc at a level returns to the previous level.
+type drillDown struct {
+	configFlags *genericclioptions.ConfigFlags
+	// namespace is the namespace given with --namespace, which skips the namespace level.
+	// Empty selects the namespace from all namespaces.
+	namespace string
+	// finderOpts are the options of the finder flags applied to the namespace and resource type levels.
+	finderOpts []fuzzyfinder.Option
+	// verbs are the verbs the command requires the resource types to support.
+	verbs []string
+	// builder returns a new builder of the objects with the selectors of the command.
+	builder func() *resource.Builder
+	// selectObject selects an object from the infos of the selected namespace and resource type.
+	selectObject func(infos []*resource.Info, header string) (*resource.Info, error)
+}
+
+// Run selects an object of the resource types given as the arguments.
+func (d *drillDown) Run(args []string) (*resource.Info, error) {
+	if len(args) == 0 {
+		return d.runWithoutTypes()
+	}
+
+	infos, err := d.infos(d.namespace, d.namespace == "", args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("resource not found")
+	}
+
+	byNamespace := make(map[string][]*resource.Info)
+	for _, info := range infos {
+		byNamespace[info.Namespace] = append(byNamespace[info.Namespace], info)
+	}
+
+	namespaces := make([]string, 0, len(byNamespace))
+	counts := make(map[string]int, len(byNamespace))
+
+	for ns, nsInfos := range byNamespace {
+		namespaces = append(namespaces, ns)
+		counts[ns] = len(nsInfos)
+	}
+
+	sort.Strings(namespaces)
+
+	if len(namespaces) == 1 {
+		info, err := d.selectObject(infos, drillDownHeader(false, namespaces[0]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		}
+
+		return info, nil
+	}
+
+	for {
+		ns, err := fuzzyfinder.Namespaces(namespaces, counts,
+			append(d.finderOpts, fuzzyfinder.WithHeader("namespace of "+strings.Join(args, " ")))...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		}
+
+		info, err := d.selectObject(byNamespace[ns], drillDownHeader(true, ns))
+		if errors.Is(err, fuzzyfinder.ErrAbort) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		}
+
+		return info, nil
+	}
+}
+
+// runWithoutTypes selects the namespace, the namespaced resource type supporting the verbs, then the object.
+// The namespace level is skipped if the namespace is given, and Esc at the resource type level aborts then.
+// The numbers of objects are not shown at the namespace level because the resource type is not selected yet.
+func (d *drillDown) runWithoutTypes() (*resource.Info, error) {
+	namespaces := []string{d.namespace}
+
+	if d.namespace == "" {
+		var err error
+
+		namespaces, err = d.namespaces()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	all, err := apiResources(d.configFlags, d.verbs...)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []kubernetes.APIResource
+
+	for _, r := range all {
+		if r.Namespaced {
+			resources = append(resources, r)
+		}
+	}
+
+	for {
+		ns := d.namespace
+
+		if ns == "" {
+			var err error
+
+			ns, err = fuzzyfinder.Namespaces(namespaces, nil,
+				append(d.finderOpts, fuzzyfinder.WithHeader("namespace"))...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+			}
+		}
+
+		notice := ""
+
+		for {
+			r, err := selectAPIResource(resources,
+				append(d.finderOpts, fuzzyfinder.WithHeader(drillDownHeader(d.namespace == "", ns, notice)))...)
+			if errors.Is(err, fuzzyfinder.ErrAbort) && d.namespace == "" {
+				break
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+			}
+
+			infos, err := d.infos(ns, false, []string{r.Name()})
+			if err != nil {
+				return nil, err
+			}
+
+			if len(infos) == 0 {
+				if !fuzzyfinder.Interactive(d.finderOpts...) {
+					return nil, fmt.Errorf("no %s found in %s", r.Name(), ns)
+				}
+
+				notice = fmt.Sprintf("no %s found", r.Name())
+
+				continue
+			}
+
+			info, err := d.selectObject(infos, drillDownHeader(true, ns, r.Name()))
+			if errors.Is(err, fuzzyfinder.ErrAbort) {
+				notice = ""
+
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+			}
+
+			return info, nil
+		}
+	}
+}
+
+// infos returns the objects of the resource types in the namespace or in all namespaces.
+func (d *drillDown) infos(namespace string, allNamespaces bool, args []string) ([]*resource.Info, error) {
+	r := d.builder().
+		NamespaceParam(namespace).AllNamespaces(allNamespaces).
+		ResourceTypeOrNameArgs(true, args...).
+		Do()
+
+	if err := r.Err(); err != nil {
+		return nil, fmt.Errorf("failed to request: %w", err)
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get infos: %w", err)
+	}
+
+	return infos, nil
+}
+
+// namespaces returns the sorted names of the namespaces.
+// The selectors of the command are not applied because they select the objects.
+func (d *drillDown) namespaces() ([]string, error) {
+	r := resource.NewBuilder(d.configFlags).
+		Unstructured().
+		ContinueOnError().
+		ResourceTypeOrNameArgs(true, "namespaces").
+		Flatten().
+		Do()
+
+	if err := r.Err(); err != nil {
+		return nil, fmt.Errorf("failed to request: %w", err)
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespaces: %w", err)
+	}
+
+	namespaces := make([]string, 0, len(infos))
+	for _, info := range infos {
+		namespaces = append(namespaces, info.Name)
+	}
+
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// drillDownNamespace returns the namespace given with --namespace restricting the drill-down,
+// or empty if it is not given or all namespaces are requested.
+func drillDownNamespace(configFlags *genericclioptions.ConfigFlags, allNamespaces bool) string {
+	if allNamespaces || configFlags.Namespace == nil {
+		return ""
+	}
+
+	return *configFlags.Namespace
+}
+
+// drillDownHeader returns the header showing the selected levels
+// and the hint of the key returning to the previous level if back is true.
+func drillDownHeader(back bool, levels ...string) string {
+	var nonEmpty []string
+
+	for _, l := range levels {
+		if l != "" {
+			nonEmpty = append(nonEmpty, l)
+		}
+	}
+
+	header := strings.Join(nonEmpty, " > ")
+	if back {
+		header += "  (esc: back)"
+	}
+
+	return header
+}
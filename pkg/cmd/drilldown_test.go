@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestDrillDownNamespace(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		allNamespaces bool
+		want          string
+	}{
+		{name: "no namespace", want: ""},
+		{name: "namespace", namespace: "kube-system", want: "kube-system"},
+		{name: "all namespaces", allNamespaces: true, want: ""},
+		{name: "namespace with all namespaces", namespace: "kube-system", allNamespaces: true, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFlags := genericclioptions.NewConfigFlags(false)
+			*configFlags.Namespace = tt.namespace
+
+			if got := drillDownNamespace(configFlags, tt.allNamespaces); got != tt.want {
+				t.Errorf("drillDownNamespace() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrillDownHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		back   bool
+		levels []string
+		want   string
+	}{
+		{name: "namespace", levels: []string{"default"}, want: "default"},
+		{name: "back", back: true, levels: []string{"default", "pods"}, want: "default > pods  (esc: back)"},
+		{name: "empty levels are skipped", back: true, levels: []string{"default", ""}, want: "default  (esc: back)"},
+		{name: "no levels", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := drillDownHeader(tt.back, tt.levels...); got != tt.want {
+				t.Errorf("drillDownHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
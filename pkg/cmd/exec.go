@@ -2,22 +2,29 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
-	"strconv"
+	"strings"
 
+	"github.com/d-kuro/kubectl-fuzzy/pkg/asciicast"
 	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
 	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
 	dockerterm "github.com/moby/term"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
 	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	exitcode "k8s.io/client-go/util/exec"
+	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util/interrupt"
 	"k8s.io/kubectl/pkg/util/term"
@@ -27,9 +34,26 @@ const (
 	exampleExec = `
 	# Selecting a Pod with the fuzzy finder and execute a command in a container
 	kubectl fuzzy exec [flags] -- COMMAND [args...]
+
+	# Selecting a Pod with the fuzzy finder and start the first shell found in the container
+	kubectl fuzzy exec -it [flags]
+
+	# Record the session to a file in the asciicast v2 format
+	kubectl fuzzy exec -it --record=session.cast [flags] -- COMMAND [args...]
+
+	# Selecting multiple Pods with the Tab key and execute a command in all of them
+	kubectl fuzzy exec --multi [flags] -- COMMAND [args...]
 `
 )
 
+const (
+	defaultMaxParallel = 5
+
+	transportAuto      = "auto"
+	transportWebSocket = "websocket"
+	transportSPDY      = "spdy"
+)
+
 // NewCmdExec provides a cobra command wrapping ExecOptions.
 func NewCmdExec(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
 	o := NewExecOptions(config, streams)
@@ -64,7 +88,6 @@ func NewCmdExec(config *genericclioptions.ConfigFlags, streams genericclioptions
 // the current context on a user's KUBECONFIG.
 type ExecOptions struct {
 	configFlags *genericclioptions.ConfigFlags
-	printFlags  *genericclioptions.JSONYamlPrintFlags
 	streamOptions
 
 	client  coreclient.CoreV1Interface
@@ -75,9 +98,16 @@ type ExecOptions struct {
 	selector      string
 	command       []string
 
-	preview       bool
-	previewFormat string
-	rawPreview    bool
+	multi       bool
+	maxParallel int
+	summary     bool
+
+	shells    []string
+	transport string
+	record    string
+
+	finder  finderFlags
+	preview previewFlags
 }
 
 // streamOptions holds information pertaining to the streaming session.
@@ -104,12 +134,24 @@ func (o *ExecOptions) AddFlags(flags *pflag.FlagSet) {
 		"Stdin is a TTY")
 
 	// original flags
-	flags.BoolVarP(&o.preview, "preview", "P", false,
-		"If true, display the object YAML|JSON by preview window for fuzzy finder selector.")
-	flags.StringVar(&o.previewFormat, "preview-format", "yaml",
-		"Preview window output format. One of json|yaml.")
-	flags.BoolVar(&o.rawPreview, "raw-preview", false,
-		"If true, display the unsimplified object in the preview window. (default is simplified)")
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
+	flags.BoolVarP(&o.multi, "multi", "m", false,
+		"If true, select multiple Pods with the Tab key and execute the command on all of them concurrently. "+
+			"The output is prefixed with the Pod name. Cannot be used with --stdin if more than one Pod is selected.")
+	flags.IntVar(&o.maxParallel, "max-parallel", defaultMaxParallel,
+		"Maximum number of Pods on which the command is executed concurrently when --multi is specified.")
+	flags.BoolVar(&o.summary, "summary", false,
+		"If true, collect the output of each Pod and print it with a summary of exit codes when --multi is specified.")
+	flags.StringSliceVar(&o.shells, "shell", []string{"bash", "ash", "sh"},
+		"Comma separated list of shells probed in order when no command is given with --stdin and --tty. "+
+			"The first shell found in the container is started.")
+	flags.StringVar(&o.transport, "transport", transportAuto,
+		"Streaming protocol used to connect to the container. "+
+			"auto tries WebSocket first and falls back to SPDY if the upgrade fails. One of auto|websocket|spdy.")
+	flags.StringVar(&o.record, "record", "",
+		"If present, record the session to the file in the asciicast v2 format. "+
+			"The recording can be played back with \"kubectl fuzzy replay\".")
 }
 
 // NewExecOptions provides an instance of ExecOptions with default values.
@@ -119,7 +161,6 @@ func NewExecOptions(config *genericclioptions.ConfigFlags, streams genericcliopt
 			IOStreams: streams,
 		},
 		configFlags: config,
-		printFlags:  genericclioptions.NewJSONYamlPrintFlags(),
 	}
 }
 
@@ -144,9 +185,7 @@ func (o *ExecOptions) Complete(cmd *cobra.Command, args []string, argsLenAtDash
 	o.client = client.CoreV1()
 	o.builder = resource.NewBuilder(o.configFlags)
 
-	if !o.preview {
-		o.preview, _ = strconv.ParseBool(os.Getenv(previewEnabledEnvVar))
-	}
+	o.preview.Complete()
 
 	if !o.allNamespaces {
 		kubeConfig := o.configFlags.ToRawKubeConfigLoader()
@@ -164,8 +203,39 @@ func (o *ExecOptions) Complete(cmd *cobra.Command, args []string, argsLenAtDash
 
 // Validate ensures that all required arguments and flag values are provided.
 func (o *ExecOptions) Validate() error {
-	if len(o.command) == 0 {
-		return fmt.Errorf("you must specify at least one command for the container")
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.finder.Validate(); err != nil {
+		return err
+	}
+
+	if len(o.command) == 0 && !o.shellMode() {
+		return fmt.Errorf("you must specify at least one command for the container, " +
+			"or --stdin and --tty to start a shell")
+	}
+
+	if o.shellMode() && len(o.shells) == 0 {
+		return fmt.Errorf("--shell must specify at least one shell")
+	}
+
+	switch o.transport {
+	case transportAuto, transportWebSocket, transportSPDY:
+	default:
+		return fmt.Errorf("invalid --transport value %q, must be one of auto|websocket|spdy", o.transport)
+	}
+
+	if o.maxParallel < 1 {
+		return fmt.Errorf("--max-parallel must be greater than 0")
+	}
+
+	if o.record != "" && o.multi {
+		return fmt.Errorf("--record cannot be used with --multi")
+	}
+
+	if o.summary && !o.multi {
+		return fmt.Errorf("--summary requires --multi")
 	}
 
 	return nil
@@ -195,36 +265,58 @@ func (o *ExecOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("resource not found")
 	}
 
-	var printer printers.ResourcePrinter
-	if o.preview {
-		printer, err = o.printFlags.ToPrinter(o.previewFormat)
-		if err != nil {
-			return err
-		}
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
+	if err != nil {
+		return err
 	}
 
-	info, err := fuzzyfinder.Infos(infos,
-		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
-		fuzzyfinder.WithPreview(printer),
-		fuzzyfinder.WithRawPreview(o.rawPreview))
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
 	if err != nil {
-		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		return err
 	}
 
-	uncastVersionedObj, err := scheme.Scheme.ConvertToVersion(info.Object, corev1.SchemeGroupVersion)
-	if err != nil {
-		return fmt.Errorf("failed to convert object: %w", err)
+	finderOpts := append(previewOpts,
+		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
+		fuzzyfinder.WithScore(hist.score))
+	finderOpts = append(finderOpts, o.finder.options(o.ErrOut)...)
+
+	var info *resource.Info
+
+	if o.multi {
+		selected, err := fuzzyfinder.InfosMulti(infos, finderOpts...)
+		if err != nil {
+			return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		}
+
+		hist.record(selected...)
+
+		if len(selected) > 1 {
+			return o.broadcast(ctx, selected)
+		}
+
+		info = selected[0]
+	} else {
+		info, err = fuzzyfinder.Infos(infos, finderOpts...)
+		if err != nil {
+			return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		}
+
+		hist.record(info)
 	}
 
-	pod, ok := uncastVersionedObj.(*corev1.Pod)
-	if !ok {
-		return fmt.Errorf("illegal types that are not pod")
+	pod, err := toPod(info)
+	if err != nil {
+		return err
 	}
 
 	var containerName string
 
 	if len(pod.Spec.Containers) > 1 {
-		container, err := fuzzyfinder.Containers(pod.Spec.Containers)
+		container, err := fuzzyfinder.Containers(pod.Spec.Containers,
+			fuzzyfinder.WithSelectOne(o.finder.selectOne),
+			fuzzyfinder.WithExitZero(o.finder.exitZero),
+			fuzzyfinder.WithPick(o.finder.pick),
+			fuzzyfinder.WithErrOut(o.ErrOut))
 		if err != nil {
 			return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
 		}
@@ -234,6 +326,18 @@ func (o *ExecOptions) Run(ctx context.Context) error {
 		containerName = pod.Spec.Containers[0].Name
 	}
 
+	if o.shellMode() {
+		shell, err := o.detectShell(ctx, pod, containerName)
+		if err != nil {
+			return err
+		}
+
+		o.command = []string{shell}
+	}
+
+	// the streams are replaced while attached
+	errOut := o.ErrOut
+
 	// ensure we can recover the terminal while attached
 	t := o.SetupTTY()
 
@@ -247,39 +351,91 @@ func (o *ExecOptions) Run(ctx context.Context) error {
 		o.ErrOut = nil
 	}
 
-	return t.Safe(o.ExecFunc(ctx, pod, containerName, t, sizeQueue))
+	if o.record == "" {
+		return t.Safe(o.ExecFunc(ctx, pod, containerName, t, sizeQueue))
+	}
+
+	f, err := os.Create(o.record)
+	if err != nil {
+		return fmt.Errorf("failed to create recording file: %w", err)
+	}
+
+	recorder, sizeQueue, err := o.setupRecording(f, t, sizeQueue)
+	if err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	execErr := t.Safe(o.ExecFunc(ctx, pod, containerName, t, sizeQueue))
+
+	recordErr := recorder.Err()
+	if err := f.Close(); err != nil && recordErr == nil {
+		recordErr = fmt.Errorf("failed to close recording file: %w", err)
+	}
+
+	if recordErr == nil {
+		return execErr
+	}
+
+	_, _ = fmt.Fprintf(errOut, "warning: the recording %s is incomplete: %v\n", o.record, recordErr)
+
+	if execErr != nil {
+		return execErr
+	}
+
+	return fmt.Errorf("failed to record the session to %s: %w", o.record, recordErr)
+}
+
+// setupRecording replaces the streams with the ones recording the session to w in the asciicast format.
+func (o *ExecOptions) setupRecording(w io.Writer, t term.TTY,
+	sizeQueue remotecommand.TerminalSizeQueue) (*asciicast.Recorder, remotecommand.TerminalSizeQueue, error) {
+	const defaultWidth, defaultHeight = 80, 24
+
+	header := asciicast.Header{
+		Width:   defaultWidth,
+		Height:  defaultHeight,
+		Command: strings.Join(o.command, " "),
+		Env: map[string]string{
+			"TERM":  os.Getenv("TERM"),
+			"SHELL": os.Getenv("SHELL"),
+		},
+	}
+
+	if size := t.GetSize(); size != nil {
+		header.Width = int(size.Width)
+		header.Height = int(size.Height)
+	}
+
+	recorder, err := asciicast.NewRecorder(w, header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+
+	if o.In != nil {
+		o.In = recorder.Reader(o.In)
+	}
+
+	if o.Out != nil {
+		o.Out = recorder.Writer(o.Out)
+	}
+
+	if o.ErrOut != nil {
+		o.ErrOut = recorder.Writer(o.ErrOut)
+	}
+
+	if sizeQueue != nil {
+		sizeQueue = recorder.SizeQueue(sizeQueue)
+	}
+
+	return recorder, sizeQueue, nil
 }
 
 // ExecFunc returns a function for executing the execute a command in a container.
 func (o *ExecOptions) ExecFunc(ctx context.Context, pod *corev1.Pod, containerName string,
 	tty term.TTY, sizeQueue remotecommand.TerminalSizeQueue) func() error {
 	fn := func() error {
-		req := o.client.RESTClient().
-			Post().
-			Resource("pods").
-			Name(pod.Name).
-			Namespace(pod.Namespace).
-			SubResource("exec").
-			VersionedParams(&corev1.PodExecOptions{
-				Container: containerName,
-				Command:   o.command,
-				Stdin:     o.stdin,
-				Stdout:    o.Out != nil,
-				Stderr:    o.ErrOut != nil,
-				TTY:       tty.Raw,
-			}, scheme.ParameterCodec)
-
-		restConfig, err := o.configFlags.ToRESTConfig()
-		if err != nil {
-			return fmt.Errorf("faild to get REST client config: %w", err)
-		}
-
-		exec, err := remotecommand.NewSPDYExecutor(restConfig, http.MethodPost, req.URL())
-		if err != nil {
-			return err
-		}
-
-		return exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		return o.stream(ctx, pod, containerName, o.command, remotecommand.StreamOptions{
 			Stdin:             o.In,
 			Stdout:            o.Out,
 			Stderr:            o.ErrOut,
@@ -291,6 +447,133 @@ func (o *ExecOptions) ExecFunc(ctx context.Context, pod *corev1.Pod, containerNa
 	return fn
 }
 
+// stream executes the command in the container and connects the streams of the options to it.
+func (o *ExecOptions) stream(ctx context.Context, pod *corev1.Pod, containerName string, command []string,
+	streamOptions remotecommand.StreamOptions) error {
+	req := o.client.RESTClient().
+		Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   command,
+			Stdin:     streamOptions.Stdin != nil,
+			Stdout:    streamOptions.Stdout != nil,
+			Stderr:    streamOptions.Stderr != nil,
+			TTY:       streamOptions.Tty,
+		}, scheme.ParameterCodec)
+
+	restConfig, err := o.configFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("faild to get REST client config: %w", err)
+	}
+
+	exec, err := o.newExecutor(restConfig, req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	return exec.StreamWithContext(ctx, streamOptions)
+}
+
+// newExecutor returns the executor for the streaming protocol specified by the transport.
+// In auto mode, WebSocket is used and SPDY is used as a fallback if the upgrade to WebSocket fails.
+func (o *ExecOptions) newExecutor(restConfig *rest.Config, u *url.URL) (remotecommand.Executor, error) {
+	switch o.transport {
+	case transportSPDY:
+		return remotecommand.NewSPDYExecutor(restConfig, http.MethodPost, u)
+	case transportWebSocket:
+		return remotecommand.NewWebSocketExecutor(restConfig, http.MethodGet, u.String())
+	}
+
+	spdyExec, err := remotecommand.NewSPDYExecutor(restConfig, http.MethodPost, u)
+	if err != nil {
+		return nil, err
+	}
+
+	websocketExec, err := remotecommand.NewWebSocketExecutor(restConfig, http.MethodGet, u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return remotecommand.NewFallbackExecutor(websocketExec, spdyExec, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// shellMode reports whether to start a shell detected in the container because no command is given.
+func (o *ExecOptions) shellMode() bool {
+	return len(o.command) == 0 && o.stdin && o.tty
+}
+
+// detectShell returns the first shell of the preference list that can be executed in the container.
+// Each shell is probed with a short non-TTY execution.
+func (o *ExecOptions) detectShell(ctx context.Context, pod *corev1.Pod, containerName string) (string, error) {
+	shell, err := firstShell(o.shells, func(shell string) error {
+		return o.stream(ctx, pod, containerName, []string{shell, "-c", "exit 0"}, remotecommand.StreamOptions{
+			Stdout: io.Discard,
+			Stderr: io.Discard,
+		})
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to detect shell in container %q: %w", containerName, err)
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, "Starting shell %q in container %q\n", shell, containerName)
+
+	return shell, nil
+}
+
+// firstShell returns the first shell the probe succeeds with.
+// The next shell is probed only if the shell is not found in the container,
+// and other errors (e.g. forbidden or connection refused) are returned as they are.
+func firstShell(shells []string, probe func(shell string) error) (string, error) {
+	for _, shell := range shells {
+		err := probe(shell)
+		if err == nil {
+			return shell, nil
+		}
+
+		if !shellNotFound(err) {
+			return "", err
+		}
+
+		klog.V(1).Infof("shell %q is not available: %s", shell, err)
+	}
+
+	return "", fmt.Errorf("no shell found, tried %s", strings.Join(shells, ", "))
+}
+
+// shellNotFound reports whether the probe failed because the shell cannot be executed in the container:
+// the exit status 126 (not executable) or 127 (not found) of the shell,
+// or the container runtime failing to start the executable.
+func shellNotFound(err error) bool {
+	var exitErr exitcode.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus() == 126 || exitErr.ExitStatus() == 127 //nolint:gomnd
+	}
+
+	msg := err.Error()
+
+	return strings.Contains(msg, "executable file not found") || strings.Contains(msg, "OCI runtime exec failed")
+}
+
+func toPod(info *resource.Info) (*corev1.Pod, error) {
+	uncastVersionedObj, err := scheme.Scheme.ConvertToVersion(info.Object, corev1.SchemeGroupVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object: %w", err)
+	}
+
+	pod, ok := uncastVersionedObj.(*corev1.Pod)
+	if !ok {
+		return nil, fmt.Errorf("illegal types that are not pod")
+	}
+
+	return pod, nil
+}
+
 func (o *streamOptions) SetupTTY() term.TTY {
 	t := term.TTY{
 		Parent: o.interruptParent,
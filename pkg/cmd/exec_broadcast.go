@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+// execResult holds the result of executing the command in a Pod.
+type execResult struct {
+	pod      *corev1.Pod
+	output   bytes.Buffer
+	exitCode int
+	err      error
+}
+
+// broadcast executes the non-interactive command on all of the selected Pods concurrently.
+func (o *ExecOptions) broadcast(ctx context.Context, infos []*resource.Info) error {
+	if o.stdin {
+		return fmt.Errorf("--stdin and --tty cannot be used when more than one Pod is selected")
+	}
+
+	pods := make([]*corev1.Pod, 0, len(infos))
+
+	for _, info := range infos {
+		pod, err := toPod(info)
+		if err != nil {
+			return err
+		}
+
+		pods = append(pods, pod)
+	}
+
+	containerName, err := selectCommonContainer(pods, o.finder, o.ErrOut)
+	if err != nil {
+		return err
+	}
+
+	results := make([]*execResult, len(pods))
+	sem := make(chan struct{}, o.maxParallel)
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for i, pod := range pods {
+		result := &execResult{pod: pod}
+		results[i] = result
+
+		// stdout and stderr are copied concurrently
+		output := &syncWriter{w: &result.output}
+
+		var stdout, stderr io.Writer = output, output
+		if !o.summary {
+			prefix := fmt.Sprintf("[%s/%s] ", pod.Namespace, pod.Name)
+			stdout = &prefixWriter{out: o.Out, mu: &mu, prefix: prefix}
+			stderr = &prefixWriter{out: o.ErrOut, mu: &mu, prefix: prefix}
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result.err = o.stream(ctx, pod, containerName, o.command, remotecommand.StreamOptions{
+				Stdout: stdout,
+				Stderr: stderr,
+			})
+
+			if w, ok := stdout.(*prefixWriter); ok {
+				w.Flush()
+			}
+
+			if w, ok := stderr.(*prefixWriter); ok {
+				w.Flush()
+			}
+
+			var exitErr exec.ExitError
+			if errors.As(result.err, &exitErr) {
+				result.exitCode = exitErr.ExitStatus()
+			} else if result.err != nil {
+				result.exitCode = -1
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if o.summary {
+		o.printSummary(results)
+	}
+
+	var failed int
+
+	for _, result := range results {
+		if result.err == nil {
+			continue
+		}
+
+		failed++
+
+		if !o.summary {
+			_, _ = fmt.Fprintf(o.ErrOut, "[%s/%s] exit code %d: %s\n",
+				result.pod.Namespace, result.pod.Name, result.exitCode, result.err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("command failed on %d of %d Pods", failed, len(results))
+	}
+
+	return nil
+}
+
+// printSummary prints the collected output of each Pod followed by a table of exit codes.
+func (o *ExecOptions) printSummary(results []*execResult) {
+	for _, result := range results {
+		_, _ = fmt.Fprintf(o.Out, "==> %s/%s <==\n", result.pod.Namespace, result.pod.Name)
+		_, _ = o.Out.Write(result.output.Bytes())
+
+		if result.output.Len() > 0 && !bytes.HasSuffix(result.output.Bytes(), []byte("\n")) {
+			_, _ = fmt.Fprintln(o.Out)
+		}
+	}
+
+	_, _ = fmt.Fprintln(o.Out)
+
+	w := printers.GetNewTabWriter(o.Out)
+	defer func() { _ = w.Flush() }()
+
+	_, _ = fmt.Fprintln(w, "NAMESPACE\tPOD\tEXIT CODE\tERROR")
+
+	for _, result := range results {
+		var errMsg string
+		if result.err != nil {
+			errMsg = result.err.Error()
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", result.pod.Namespace, result.pod.Name, result.exitCode, errMsg)
+	}
+}
+
+// selectCommonContainer selects the container in which the command is executed.
+// Only the container names all of the Pods have are candidates.
+// If there is more than one candidate, the container is selected with the fuzzy finder.
+func selectCommonContainer(pods []*corev1.Pod, finder finderFlags, errOut io.Writer) (string, error) {
+	containers := commonContainers(pods)
+	if len(containers) == 0 {
+		return "", fmt.Errorf("the selected Pods have no container name in common")
+	}
+
+	if len(containers) == 1 {
+		return containers[0].Name, nil
+	}
+
+	container, err := fuzzyfinder.Containers(containers,
+		fuzzyfinder.WithSelectOne(finder.selectOne),
+		fuzzyfinder.WithExitZero(finder.exitZero),
+		fuzzyfinder.WithPick(finder.pick),
+		fuzzyfinder.WithErrOut(errOut))
+	if err != nil {
+		return "", fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	return container.Name, nil
+}
+
+// commonContainers returns the containers of the first Pod whose names all of the Pods have.
+func commonContainers(pods []*corev1.Pod) []corev1.Container {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int)
+
+	for _, pod := range pods {
+		seen := make(map[string]bool)
+
+		for _, container := range pod.Spec.Containers {
+			if !seen[container.Name] {
+				seen[container.Name] = true
+				counts[container.Name]++
+			}
+		}
+	}
+
+	var containers []corev1.Container
+
+	for _, container := range pods[0].Spec.Containers {
+		if counts[container.Name] == len(pods) {
+			containers = append(containers, container)
+		}
+	}
+
+	return containers
+}
+
+// syncWriter serializes the writes to the writer shared by goroutines.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.w.Write(p)
+}
+
+// prefixWriter writes each line with the prefix.
+// Lines are written atomically to the output shared with other writers.
+type prefixWriter struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.writeLine(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the buffered incomplete line, if any.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) == 0 {
+		return
+	}
+
+	w.writeLine(append(w.buf, '\n'))
+	w.buf = nil
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, _ = io.WriteString(w.out, w.prefix)
+	_, _ = w.out.Write(line)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCommonContainers(t *testing.T) {
+	pod := func(names ...string) *corev1.Pod {
+		p := &corev1.Pod{}
+		for _, name := range names {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: name})
+		}
+
+		return p
+	}
+
+	tests := []struct {
+		name string
+		pods []*corev1.Pod
+		want []string
+	}{
+		{name: "no pods", pods: nil, want: nil},
+		{name: "single pod", pods: []*corev1.Pod{pod("app", "sidecar")}, want: []string{"app", "sidecar"}},
+		{name: "intersection", pods: []*corev1.Pod{pod("app", "sidecar"), pod("sidecar", "app", "debug")},
+			want: []string{"app", "sidecar"}},
+		{name: "only some pods", pods: []*corev1.Pod{pod("app", "sidecar"), pod("app")}, want: []string{"app"}},
+		{name: "nothing in common", pods: []*corev1.Pod{pod("app"), pod("web")}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range commonContainers(tt.pods) {
+				got = append(got, c.Name)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("commonContainers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var (
+		out bytes.Buffer
+		mu  sync.Mutex
+	)
+
+	w := &prefixWriter{out: &out, mu: &mu, prefix: "[ns/pod] "}
+
+	_, _ = w.Write([]byte("first\nsec"))
+	_, _ = w.Write([]byte("ond\nlast"))
+	w.Flush()
+
+	want := "[ns/pod] first\n[ns/pod] second\n[ns/pod] last\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSyncWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w := &syncWriter{w: &buf}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_, _ = w.Write([]byte("x"))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if buf.Len() != 800 {
+		t.Errorf("written %d bytes, want 800", buf.Len())
+	}
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gwebsocket "github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	utilremotecommand "k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	exitcode "k8s.io/client-go/util/exec"
+)
+
+const (
+	streamWebSocketOutput = "hello over websocket"
+	streamSPDYOutput      = "hello over spdy"
+)
+
+func TestNewExecutor(t *testing.T) {
+	tests := []struct {
+		name string
+		// websocket handles the WebSocket upgrade requests.
+		websocket http.HandlerFunc
+		// proxyErr is returned by the proxy of the first connection, which is the WebSocket one.
+		proxyErr error
+		want     string
+	}{
+		{
+			name:      "websocket v5",
+			websocket: webSocketV5Handler(streamWebSocketOutput),
+			want:      streamWebSocketOutput,
+		},
+		{
+			name: "failed upgrade falls back to spdy",
+			websocket: func(w http.ResponseWriter, req *http.Request) {
+				http.Error(w, "upgrade not supported", http.StatusBadRequest)
+			},
+			want: streamSPDYOutput,
+		},
+		{
+			name: "https proxy error falls back to spdy",
+			websocket: func(w http.ResponseWriter, req *http.Request) {
+				t.Error("unexpected websocket request through the failed proxy")
+			},
+			proxyErr: errors.New("proxy: unknown scheme: https"),
+			want:     streamSPDYOutput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if gwebsocket.IsWebSocketUpgrade(req) {
+					tt.websocket(w, req)
+
+					return
+				}
+
+				spdyHandler(t, streamSPDYOutput)(w, req)
+			}))
+			defer server.Close()
+
+			u, err := url.Parse(server.URL + "/api/v1/namespaces/demo/pods/app/exec?stdout=true")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var dials int32
+
+			restConfig := &rest.Config{
+				Host: server.URL,
+				Proxy: func(*http.Request) (*url.URL, error) {
+					if atomic.AddInt32(&dials, 1) == 1 && tt.proxyErr != nil {
+						return nil, tt.proxyErr
+					}
+
+					return nil, nil
+				},
+			}
+
+			o := &ExecOptions{transport: transportAuto}
+
+			executor, err := o.newExecutor(restConfig, u)
+			if err != nil {
+				t.Fatalf("newExecutor() error = %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			var stdout bytes.Buffer
+
+			if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout}); err != nil {
+				t.Fatalf("StreamWithContext() error = %v", err)
+			}
+
+			if stdout.String() != tt.want {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+// webSocketV5Handler returns the handler writing the output to stdout
+// and a success status in the v5 WebSocket streaming protocol.
+func webSocketV5Handler(output string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		upgrader := gwebsocket.Upgrader{Subprotocols: []string{utilremotecommand.StreamProtocolV5Name}}
+
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		messages := [][]byte{
+			append([]byte{utilremotecommand.StreamStdOut}, output...),
+			append([]byte{utilremotecommand.StreamErr}, `{"metadata":{},"status":"Success"}`...),
+		}
+
+		for _, m := range messages {
+			if err := conn.WriteMessage(gwebsocket.BinaryMessage, m); err != nil {
+				return
+			}
+		}
+
+		_ = conn.WriteMessage(gwebsocket.CloseMessage,
+			gwebsocket.FormatCloseMessage(gwebsocket.CloseNormalClosure, ""))
+	}
+}
+
+// spdyHandler returns the handler writing the output to stdout in the v4 SPDY streaming protocol.
+func spdyHandler(t *testing.T, output string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if _, err := httpstream.Handshake(req, w, []string{utilremotecommand.StreamProtocolV4Name}); err != nil {
+			t.Errorf("failed to negotiate protocol: %v", err)
+
+			return
+		}
+
+		streams := make(chan httpstream.Stream, 2) //nolint:gomnd
+
+		conn := spdy.NewResponseUpgrader().UpgradeResponse(w, req,
+			func(stream httpstream.Stream, replySent <-chan struct{}) error {
+				go func() {
+					<-replySent
+					streams <- stream
+				}()
+
+				return nil
+			})
+		if conn == nil {
+			t.Error("failed to upgrade to spdy")
+
+			return
+		}
+		defer conn.Close()
+
+		var stdout, errStream httpstream.Stream
+
+		for stdout == nil || errStream == nil {
+			select {
+			case stream := <-streams:
+				switch stream.Headers().Get(corev1.StreamType) {
+				case corev1.StreamTypeStdout:
+					stdout = stream
+				case corev1.StreamTypeError:
+					errStream = stream
+				}
+			case <-time.After(5 * time.Second):
+				t.Error("timed out waiting for streams")
+
+				return
+			}
+		}
+
+		_, _ = stdout.Write([]byte(output))
+		_ = stdout.Close()
+		// an empty error stream means success
+		_ = errStream.Close()
+	}
+}
+
+func TestFirstShell(t *testing.T) {
+	notFound := exitcode.CodeExitError{Err: errors.New("command terminated with exit code 127"), Code: 127}
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods/exec"}, "app", errors.New("denied"))
+
+	tests := []struct {
+		name string
+		// errs are the errors of the probes by shell, nil if the shell is available.
+		errs    map[string]error
+		want    string
+		wantErr error
+		// wantProbes are the shells probed in order.
+		wantProbes []string
+	}{
+		{
+			name:       "first shell",
+			errs:       map[string]error{},
+			want:       "bash",
+			wantProbes: []string{"bash"},
+		},
+		{
+			name:       "exit code 127 tries the next shell",
+			errs:       map[string]error{"bash": notFound},
+			want:       "sh",
+			wantProbes: []string{"bash", "sh"},
+		},
+		{
+			name: "exit code 126 tries the next shell",
+			errs: map[string]error{
+				"bash": exitcode.CodeExitError{Err: errors.New("command terminated with exit code 126"), Code: 126},
+			},
+			want:       "sh",
+			wantProbes: []string{"bash", "sh"},
+		},
+		{
+			name: "runtime failing to start the executable tries the next shell",
+			errs: map[string]error{
+				"bash": errors.New(`OCI runtime exec failed: exec failed: unable to start container process: ` +
+					`exec: "bash": executable file not found in $PATH: unknown`),
+			},
+			want:       "sh",
+			wantProbes: []string{"bash", "sh"},
+		},
+		{
+			name:       "other exit code is returned",
+			errs:       map[string]error{"bash": exitcode.CodeExitError{Err: errors.New("exit 1"), Code: 1}},
+			wantErr:    exitcode.CodeExitError{Err: errors.New("exit 1"), Code: 1},
+			wantProbes: []string{"bash"},
+		},
+		{
+			name:       "forbidden is returned",
+			errs:       map[string]error{"bash": forbidden},
+			wantErr:    forbidden,
+			wantProbes: []string{"bash"},
+		},
+		{
+			name:       "connection refused is returned",
+			errs:       map[string]error{"bash": errors.New("dial tcp 127.0.0.1:6443: connect: connection refused")},
+			wantErr:    errors.New("dial tcp 127.0.0.1:6443: connect: connection refused"),
+			wantProbes: []string{"bash"},
+		},
+		{
+			name:       "no shell found",
+			errs:       map[string]error{"bash": notFound, "sh": notFound},
+			wantErr:    errors.New("no shell found, tried bash, sh"),
+			wantProbes: []string{"bash", "sh"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var probes []string
+
+			got, err := firstShell([]string{"bash", "sh"}, func(shell string) error {
+				probes = append(probes, shell)
+
+				return tt.errs[shell]
+			})
+
+			if (err == nil) != (tt.wantErr == nil) || err != nil && err.Error() != tt.wantErr.Error() {
+				t.Fatalf("firstShell() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("firstShell() = %q, want %q", got, tt.want)
+			}
+
+			if strings.Join(probes, ",") != strings.Join(tt.wantProbes, ",") {
+				t.Errorf("probes = %q, want %q", probes, tt.wantProbes)
+			}
+		})
+	}
+}
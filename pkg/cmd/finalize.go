@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	fuzzyprinters "github.com/d-kuro/kubectl-fuzzy/pkg/printers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	exampleFinalize = `
+	# Selecting an object stuck in Terminating with the fuzzy finder and remove the selected finalizers
+	kubectl fuzzy finalize TYPE [flags]
+
+	# Remove all finalizers of the selected object without confirmation
+	kubectl fuzzy finalize TYPE --all --yes [flags]
+`
+
+	finalizerWarning = "warning: removing finalizers skips the cleanup performed by the controllers owning them. " +
+		"External resources may be leaked and dependents may be left behind."
+)
+
+// NewCmdFinalize provides a cobra command wrapping FinalizeOptions.
+func NewCmdFinalize(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewFinalizeOptions(config, streams)
+
+	cmd := &cobra.Command{
+		Use:           "finalize",
+		Short:         "Selecting an object stuck in Terminating with the fuzzy finder and remove its finalizers",
+		Example:       exampleFinalize,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			return o.Run(c.Context(), args)
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// FinalizeOptions provides information required to remove finalizers from objects being deleted.
+type FinalizeOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+
+	allNamespaces bool
+	namespace     string
+	selector      string
+	all           bool
+	yes           bool
+
+	finder  finderFlags
+	preview previewFlags
+}
+
+// NewFinalizeOptions provides an instance of FinalizeOptions with default values.
+func NewFinalizeOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *FinalizeOptions {
+	return &FinalizeOptions{
+		configFlags: config,
+		preview: previewFlags{
+			always: true,
+			wrapPrinter: func(printer printers.ResourcePrinter) printers.ResourcePrinter {
+				return &fuzzyprinters.Finalizers{Delegate: printer}
+			},
+		},
+		IOStreams: streams,
+	}
+}
+
+// AddFlags adds a flag to the flag set.
+func (o *FinalizeOptions) AddFlags(flags *pflag.FlagSet) {
+	// kubectl flags
+	flags.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false,
+		"If present, list the requested object(s) across all namespaces. "+
+			"Namespace in current context is ignored even if specified with --namespace.")
+	flags.StringVarP(&o.selector, "selector", "l", "",
+		"Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+
+	// original flags
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
+	flags.BoolVar(&o.all, "all", false,
+		"If true, remove all finalizers of the selected object instead of selecting them with the fuzzy finder.")
+	flags.BoolVarP(&o.yes, "yes", "y", false,
+		"If true, remove the finalizers without confirmation.")
+}
+
+// Complete sets all information required for removing finalizers.
+func (o *FinalizeOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.preview.Complete()
+
+	if !o.allNamespaces {
+		namespace, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return fmt.Errorf("faild to get namespace from kube config: %w", err)
+		}
+
+		o.namespace = namespace
+	}
+
+	return nil
+}
+
+// Validate ensures that all required arguments and flag values are provided.
+func (o *FinalizeOptions) Validate() error {
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.finder.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run execute fizzy finder and remove finalizers.
+func (o *FinalizeOptions) Run(ctx context.Context, args []string) error {
+	r := resource.NewBuilder(o.configFlags).
+		Unstructured().
+		ContinueOnError().
+		NamespaceParam(o.namespace).DefaultNamespace().AllNamespaces(o.allNamespaces).
+		LabelSelectorParam(o.selector).
+		ResourceTypeOrNameArgs(true, args...).
+		Flatten().
+		Do()
+
+	if err := r.Err(); err != nil {
+		return fmt.Errorf("failed to request: %w", err)
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return fmt.Errorf("failed to get infos: %w", err)
+	}
+
+	infos = terminatingInfos(infos)
+	if len(infos) == 0 {
+		return fmt.Errorf("no object with a deletionTimestamp and finalizers found")
+	}
+
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
+	if err != nil {
+		return err
+	}
+
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
+	if err != nil {
+		return err
+	}
+
+	finderOpts := append(previewOpts,
+		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
+		fuzzyfinder.WithScore(hist.score))
+
+	info, err := fuzzyfinder.Infos(infos, append(finderOpts, o.finder.options(o.ErrOut)...)...)
+	if err != nil {
+		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	hist.record(info)
+
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return fmt.Errorf("failed to get object metadata: %w", err)
+	}
+
+	remove := accessor.GetFinalizers()
+	if !o.all {
+		remove, err = fuzzyfinder.Finalizers(accessor.GetFinalizers(), o.finder.levelOptions(o.ErrOut)...)
+		if err != nil {
+			return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+		}
+	}
+
+	_, _ = fmt.Fprintln(o.ErrOut, finalizerWarning)
+
+	if !o.yes {
+		_, _ = fmt.Fprintf(o.ErrOut, "Remove finalizers %s from %s %q? [y/N]: ",
+			strings.Join(remove, ", "), strings.ToLower(info.Mapping.GroupVersionKind.Kind), info.Name)
+
+		answer, err := bufio.NewReader(o.In).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			return fmt.Errorf("removing finalizers canceled")
+		}
+	}
+
+	if err := removeFinalizers(info, remove); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(o.Out, "%s \"%s\" finalizers removed: %s\n",
+		strings.ToLower(info.Mapping.GroupVersionKind.Kind), info.Name, strings.Join(remove, ", "))
+
+	return nil
+}
+
+// terminatingInfos returns the infos of the objects being deleted and waiting for finalizers.
+func terminatingInfos(infos []*resource.Info) []*resource.Info {
+	var terminating []*resource.Info
+
+	for _, info := range infos {
+		accessor, err := meta.Accessor(info.Object)
+		if err != nil {
+			continue
+		}
+
+		if accessor.GetDeletionTimestamp() != nil && len(accessor.GetFinalizers()) > 0 {
+			terminating = append(terminating, info)
+		}
+	}
+
+	return terminating
+}
+
+// removeFinalizers removes the finalizers from the object.
+// The patch fails if the finalizers of the object have been changed since the object was fetched.
+func removeFinalizers(info *resource.Info, remove []string) error {
+	accessor, err := meta.Accessor(info.Object)
+	if err != nil {
+		return fmt.Errorf("failed to get object metadata: %w", err)
+	}
+
+	patch, err := finalizersPatch(accessor.GetFinalizers(), remove)
+	if err != nil {
+		return fmt.Errorf("failed to create patch: %w", err)
+	}
+
+	obj, err := resource.NewHelper(info.Client, info.Mapping).
+		Patch(info.Namespace, info.Name, types.JSONPatchType, patch, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove finalizers: %w", err)
+	}
+
+	return info.Refresh(obj, true)
+}
+
+// finalizersPatch returns the JSON patch replacing the current finalizers with the ones not removed.
+// The test operation makes the patch fail if the finalizers have been changed.
+func finalizersPatch(current, remove []string) ([]byte, error) {
+	if current == nil {
+		current = []string{}
+	}
+
+	removeSet := make(map[string]bool, len(remove))
+	for _, f := range remove {
+		removeSet[f] = true
+	}
+
+	remaining := make([]string, 0, len(current))
+
+	for _, f := range current {
+		if !removeSet[f] {
+			remaining = append(remaining, f)
+		}
+	}
+
+	return json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/metadata/finalizers", "value": current},
+		{"op": "replace", "path": "/metadata/finalizers", "value": remaining},
+	})
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestFinalizersPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		remove  []string
+		want    string
+	}{
+		{
+			name:    "remove one",
+			current: []string{"a.example.com/x", "kubernetes.io/pv-protection"},
+			remove:  []string{"kubernetes.io/pv-protection"},
+			want: `[{"op":"test","path":"/metadata/finalizers","value":["a.example.com/x","kubernetes.io/pv-protection"]},` +
+				`{"op":"replace","path":"/metadata/finalizers","value":["a.example.com/x"]}]`,
+		},
+		{
+			name:    "remove all",
+			current: []string{"a.example.com/x"},
+			remove:  []string{"a.example.com/x"},
+			want: `[{"op":"test","path":"/metadata/finalizers","value":["a.example.com/x"]},` +
+				`{"op":"replace","path":"/metadata/finalizers","value":[]}]`,
+		},
+		{
+			name:   "no finalizers",
+			remove: []string{"a.example.com/x"},
+			want: `[{"op":"test","path":"/metadata/finalizers","value":[]},` +
+				`{"op":"replace","path":"/metadata/finalizers","value":[]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := finalizersPatch(tt.current, tt.remove)
+			if err != nil {
+				t.Fatalf("finalizersPatch() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("finalizersPatch() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
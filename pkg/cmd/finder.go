@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/spf13/pflag"
+)
+
+// objectFlag is the hidden flag re-selecting the object of a history entry.
+const objectFlag = "object"
+
+// finderFlags provides the flags controlling the fuzzy finder for scripting.
+type finderFlags struct {
+	query     string
+	selectOne bool
+	exitZero  bool
+	pick      string
+	noHistory bool
+	// object restricts the candidates to the object in the form of NAMESPACE/NAME or NAME.
+	// It is hidden and used to re-run a command of the history.
+	object string
+}
+
+// AddFlags adds a flag to the flag set.
+func (f *finderFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&f.query, "query", "q", "",
+		"Initial query of the fuzzy finder.")
+	flags.BoolVarP(&f.selectOne, "select-1", "1", false,
+		"If true, select the candidate without starting the fuzzy finder if exactly one candidate matches the query.")
+	flags.BoolVarP(&f.exitZero, "exit-0", "0", false,
+		"If true, exit with an error without starting the fuzzy finder if no candidates match the query.")
+	flags.StringVar(&f.pick, "pick", "",
+		"Pick the candidate matching the query without starting the fuzzy finder. One of "+
+			strings.Join(fuzzyfinder.Picks(), "|")+". "+
+			"Without --pick, best is used only if the terminal cannot be opened (e.g. in CI), "+
+			"which is checked with /dev/tty so that redirecting stdin or stdout still starts the fuzzy finder, "+
+			"and an error is returned instead of picking if the query is empty and more than one candidate matches.")
+	flags.BoolVar(&f.noHistory, "no-history", false,
+		"If true, do not record the selection in the history and do not rank the candidates by the history.")
+	flags.StringVar(&f.object, objectFlag, "",
+		"Restrict the candidates to the object NAMESPACE/NAME, or NAME if it is cluster-scoped.")
+	_ = flags.MarkHidden(objectFlag)
+}
+
+// options returns the fuzzy finder options of the flags.
+func (f *finderFlags) options(errOut io.Writer) []fuzzyfinder.Option {
+	opts := []fuzzyfinder.Option{
+		fuzzyfinder.WithQuery(f.query),
+		fuzzyfinder.WithSelectOne(f.selectOne),
+		fuzzyfinder.WithExitZero(f.exitZero),
+		fuzzyfinder.WithPick(f.pick),
+		fuzzyfinder.WithErrOut(errOut),
+	}
+
+	if f.object != "" {
+		namespace, name, ok := strings.Cut(f.object, "/")
+		if !ok {
+			namespace, name = "", f.object
+		}
+
+		opts = append(opts, fuzzyfinder.WithObject(namespace, name))
+	}
+
+	return opts
+}
+
+// levelOptions returns the fuzzy finder options of the flags for the levels selected before the object,
+// such as the namespace and the resource type. The query is for the object and is not applied to them.
+func (f *finderFlags) levelOptions(errOut io.Writer) []fuzzyfinder.Option {
+	return append(f.options(errOut), fuzzyfinder.WithQuery(""))
+}
+
+// Validate ensures that the --pick value is valid.
+func (f *finderFlags) Validate() error {
+	if f.pick == "" {
+		return nil
+	}
+
+	for _, p := range fuzzyfinder.Picks() {
+		if f.pick == p {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid --pick value %q, must be one of %s", f.pick, strings.Join(fuzzyfinder.Picks(), "|"))
+}
@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/config"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/history"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	exampleHistory = `
+	# Selecting an object selected before in the current context with the fuzzy finder and re-run the command
+	kubectl fuzzy history [flags]
+
+	# Print the command instead of running it
+	kubectl fuzzy history --print [flags]
+`
+)
+
+// NewCmdHistory provides a cobra command wrapping HistoryOptions.
+func NewCmdHistory(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewHistoryOptions(config, streams)
+
+	cmd := &cobra.Command{
+		Use:           "history",
+		Short:         "Selecting a past selection with the fuzzy finder and re-run the command",
+		Example:       exampleHistory,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			return o.Run(c.Context())
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// HistoryOptions provides information required to re-run the commands of the selection history.
+type HistoryOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+
+	allContexts bool
+	print       bool
+
+	path    string
+	context string
+}
+
+// NewHistoryOptions provides an instance of HistoryOptions with default values.
+func NewHistoryOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *HistoryOptions {
+	return &HistoryOptions{
+		configFlags: config,
+		IOStreams:   streams,
+	}
+}
+
+// AddFlags adds a flag to the flag set.
+func (o *HistoryOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&o.allContexts, "all-contexts", false,
+		"If true, list the selections of all contexts. By default, only the selections of the current context are listed.")
+	flags.BoolVar(&o.print, "print", false,
+		"If true, print the command instead of running it.")
+}
+
+// Complete sets all information required for history.
+func (o *HistoryOptions) Complete(cmd *cobra.Command, args []string) error {
+	c, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	o.path = c.History.Path
+
+	o.context, _, err = kubernetes.CurrentContext(o.configFlags)
+	if err != nil {
+		return fmt.Errorf("failed to get current context: %w", err)
+	}
+
+	return nil
+}
+
+// Validate ensures that all required arguments and flag values are provided.
+func (o *HistoryOptions) Validate() error {
+	return nil
+}
+
+// Run execute fizzy finder and re-run the command of the selected history entry.
+func (o *HistoryOptions) Run(ctx context.Context) error {
+	entries, err := history.Load(o.path)
+	if err != nil {
+		return err
+	}
+
+	// newest first
+	var candidates []history.Entry
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		if o.allContexts || entries[i].Context == o.context {
+			candidates = append(candidates, entries[i])
+		}
+	}
+
+	if len(candidates) == 0 {
+		return fmt.Errorf("history not found")
+	}
+
+	entry, err := selectHistoryEntry(candidates)
+	if err != nil {
+		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	if o.print {
+		_, _ = fmt.Fprintln(o.Out, history.CommandLine(rerunArgs(entry)))
+
+		return nil
+	}
+
+	// the credentials are not recorded, pass the ones given to this command
+	args := rerunArgs(entry, credentialArgs(o.configFlags)...)
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable: %w", err)
+	}
+
+	c := exec.CommandContext(ctx, executable, args...)
+	c.Stdin, c.Stdout, c.Stderr = o.In, o.Out, o.ErrOut
+
+	return c.Run()
+}
+
+// selectHistoryEntry selects a history entry with the fuzzy finder.
+// The preview window shows the context and the command line of the entry.
+func selectHistoryEntry(entries []history.Entry) (history.Entry, error) {
+	lines := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		line := fmt.Sprintf("%s %s/%s",
+			e.Time.Local().Format(time.DateTime),
+			strings.ToLower(schema.GroupKind{Group: e.Group, Kind: e.Kind}.String()),
+			e.Name)
+
+		if len(e.Namespace) >= 1 {
+			line += fmt.Sprintf(" (%s)", e.Namespace)
+		}
+
+		lines = append(lines, line)
+	}
+
+	idx, err := fuzzyfinder.Lines(lines,
+		fuzzyfinder.WithPreviewFunc(func(i int) string {
+			return fmt.Sprintf("context: %s\ncommand: %s\n", entries[i].Context, history.CommandLine(entries[i].Args))
+		}))
+	if err != nil {
+		return history.Entry{}, err
+	}
+
+	return entries[idx], nil
+}
+
+// rerunArgs returns the arguments of the entry re-selecting the same object by its namespace and name.
+// The context, the namespace, the object and the extra flags are added before the arguments after "--".
+func rerunArgs(entry history.Entry, extra ...string) []string {
+	object := entry.Name
+	flags := []string{"--context", entry.Context}
+
+	if entry.Namespace != "" {
+		object = entry.Namespace + "/" + entry.Name
+		flags = append(flags, "--namespace", entry.Namespace)
+	}
+
+	flags = append(append(flags, "--"+objectFlag, object), extra...)
+
+	args := make([]string, 0, len(entry.Args)+len(flags))
+
+	for i, arg := range entry.Args {
+		if arg == "--" {
+			args = append(args, flags...)
+
+			return append(args, entry.Args[i:]...)
+		}
+
+		args = append(args, arg)
+	}
+
+	return append(args, flags...)
+}
+
+// credentialFlags returns the names of the kubeconfig flags whose values are credentials.
+func credentialFlags() []string {
+	return []string{"token", "username", "password", "client-key", "client-certificate"}
+}
+
+// historyArgs returns the arguments without the credential flags and their values
+// so that the credentials are not saved in the history file.
+// The arguments after "--" are passed to the command in the container and are kept.
+func historyArgs(args []string) []string {
+	kept := make([]string, 0, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(kept, args[i:]...)
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !strings.HasPrefix(arg, "--") || !slices.Contains(credentialFlags(), name) {
+			kept = append(kept, arg)
+
+			continue
+		}
+
+		if !hasValue {
+			i++ // skip the value
+		}
+	}
+
+	return kept
+}
+
+// credentialArgs returns the credential flags given to the command.
+func credentialArgs(configFlags *genericclioptions.ConfigFlags) []string {
+	values := map[string]*string{
+		"token":              configFlags.BearerToken,
+		"username":           configFlags.Username,
+		"password":           configFlags.Password,
+		"client-key":         configFlags.KeyFile,
+		"client-certificate": configFlags.CertFile,
+	}
+
+	var args []string
+
+	for _, name := range credentialFlags() {
+		if value := values[name]; value != nil && *value != "" {
+			args = append(args, "--"+name+"="+*value)
+		}
+	}
+
+	return args
+}
+
+// selectionHistory records the objects selected with the fuzzy finder in the current context
+// and ranks the candidates by frecency. A nil selectionHistory is disabled.
+type selectionHistory struct {
+	path    string
+	context string
+	scores  map[history.Key]float64
+	errOut  io.Writer
+}
+
+// newSelectionHistory returns the history of the current context,
+// or nil if the history is disabled by the flag or the config file.
+func newSelectionHistory(configFlags *genericclioptions.ConfigFlags, disabled bool,
+	errOut io.Writer) (*selectionHistory, error) {
+	if disabled {
+		return nil, nil
+	}
+
+	c, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	contextName, _, err := kubernetes.CurrentContext(configFlags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current context: %w", err)
+	}
+
+	enabled, err := c.History.Enabled(contextName)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	entries, err := history.Load(c.History.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &selectionHistory{
+		path:    c.History.Path,
+		context: contextName,
+		scores:  history.Frecency(entries, time.Now()),
+		errOut:  errOut,
+	}, nil
+}
+
+// score returns the frecency score of the info.
+func (h *selectionHistory) score(info *resource.Info) float64 {
+	if h == nil {
+		return 0
+	}
+
+	return h.scores[h.key(info)]
+}
+
+// record appends the selected infos to the history file.
+// A failure is reported as a warning because the selection has already been made.
+func (h *selectionHistory) record(infos ...*resource.Info) {
+	if h == nil {
+		return
+	}
+
+	for _, info := range infos {
+		gvk := info.Mapping.GroupVersionKind
+
+		err := history.Append(h.path, history.Entry{
+			Time:      time.Now(),
+			Context:   h.context,
+			Namespace: info.Namespace,
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Name:      info.Name,
+			Args:      historyArgs(os.Args[1:]),
+		})
+		if err != nil {
+			_, _ = fmt.Fprintf(h.errOut, "warning: failed to record history: %v\n", err)
+		}
+	}
+}
+
+func (h *selectionHistory) key(info *resource.Info) history.Key {
+	return history.Key{
+		Context:   h.context,
+		Namespace: info.Namespace,
+		GroupKind: info.Mapping.GroupVersionKind.GroupKind(),
+		Name:      info.Name,
+	}
+}
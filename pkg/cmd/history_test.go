@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/history"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func TestRerunArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry history.Entry
+		extra []string
+		want  []string
+	}{
+		{
+			name:  "namespaced object",
+			entry: history.Entry{Context: "kind", Namespace: "default", Name: "nginx", Args: []string{"logs", "-q", "ngx"}},
+			want: []string{"logs", "-q", "ngx",
+				"--context", "kind", "--namespace", "default", "--object", "default/nginx"},
+		},
+		{
+			name:  "cluster-scoped object",
+			entry: history.Entry{Context: "kind", Name: "node-1", Args: []string{"describe", "nodes"}},
+			want:  []string{"describe", "nodes", "--context", "kind", "--object", "node-1"},
+		},
+		{
+			name: "flags before the command of the container",
+			entry: history.Entry{
+				Context: "kind", Namespace: "default", Name: "nginx",
+				Args: []string{"exec", "-A", "--", "sh", "-c", "ls"},
+			},
+			extra: []string{"--token=secret"},
+			want: []string{"exec", "-A",
+				"--context", "kind", "--namespace", "default", "--object", "default/nginx", "--token=secret",
+				"--", "sh", "-c", "ls"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rerunArgs(tt.entry, tt.extra...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rerunArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "no credentials", args: []string{"logs", "-n", "default"}, want: []string{"logs", "-n", "default"}},
+		{
+			name: "separate values",
+			args: []string{"logs", "--token", "secret", "--username", "admin", "--password", "pass", "-n", "default"},
+			want: []string{"logs", "-n", "default"},
+		},
+		{
+			name: "inline values",
+			args: []string{"logs", "--token=secret", "--client-key=/tmp/key", "--client-certificate=/tmp/crt", "-f"},
+			want: []string{"logs", "-f"},
+		},
+		{
+			name: "similar flags are kept",
+			args: []string{"logs", "--token-file", "x", "--kubeconfig=/tmp/config"},
+			want: []string{"logs", "--token-file", "x", "--kubeconfig=/tmp/config"},
+		},
+		{
+			name: "args of the container are kept",
+			args: []string{"exec", "--token", "secret", "--", "curl", "--token", "abc"},
+			want: []string{"exec", "--", "curl", "--token", "abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := historyArgs(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("historyArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCredentialArgs(t *testing.T) {
+	configFlags := genericclioptions.NewConfigFlags(false)
+	*configFlags.BearerToken = "secret"
+	*configFlags.KeyFile = "/tmp/key"
+
+	want := []string{"--token=secret", "--client-key=/tmp/key"}
+	if got := credentialArgs(configFlags); !reflect.DeepEqual(got, want) {
+		t.Errorf("credentialArgs() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/config"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	lineTemplateGoTemplate = "go-template="
+	lineTemplateJSONPath   = "jsonpath="
+)
+
+// candidateLines returns the function rendering the candidate line of the info with the template,
+// or with the template of the finder config for the kind of the info if the template is empty.
+// The function returns an empty string if no template is found or the rendering fails,
+// e.g. a field referred to by the Go template is missing, so that the default line is displayed.
+func candidateLines(template string, finder config.Finder) (func(info *resource.Info) string, error) {
+	if template != "" {
+		finder = config.Finder{}
+	}
+
+	// the printers are parsed once per template
+	linePrinters := make(map[string]printers.ResourcePrinter)
+
+	linePrinter := func(template string) (printers.ResourcePrinter, error) {
+		if p, ok := linePrinters[template]; ok {
+			return p, nil
+		}
+
+		p, err := newLinePrinter(template)
+		if err != nil {
+			return nil, err
+		}
+
+		linePrinters[template] = p
+
+		return p, nil
+	}
+
+	if template != "" {
+		if _, err := linePrinter(template); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, line := range finder.Lines {
+		if _, err := linePrinter(line.Template); err != nil {
+			return nil, fmt.Errorf("invalid line template in config file: %w", err)
+		}
+	}
+
+	return func(info *resource.Info) string {
+		t := template
+		if t == "" {
+			t = finder.LineTemplate(info.Mapping.GroupVersionKind.GroupKind())
+		}
+
+		if t == "" {
+			return ""
+		}
+
+		p, err := linePrinter(t)
+		if err != nil {
+			return ""
+		}
+
+		var buf bytes.Buffer
+
+		if err := p.PrintObj(info.Object, &buf); err != nil {
+			return ""
+		}
+
+		// the candidate must be a single line
+		return strings.ReplaceAll(strings.TrimSpace(buf.String()), "\n", " ")
+	}, nil
+}
+
+// newLinePrinter returns the printer rendering the Go template or the JSONPath template prefixed with "jsonpath=".
+// Missing fields fail the Go template instead of rendering "<no value>", while the JSONPath template renders them empty.
+func newLinePrinter(template string) (printers.ResourcePrinter, error) {
+	if t, ok := strings.CutPrefix(template, lineTemplateJSONPath); ok {
+		p, err := printers.NewJSONPathPrinter(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid line template %q: %w", template, err)
+		}
+
+		p.AllowMissingKeys(true)
+
+		return p, nil
+	}
+
+	p, err := printers.NewGoTemplatePrinter([]byte(strings.TrimPrefix(template, lineTemplateGoTemplate)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid line template %q: %w", template, err)
+	}
+
+	p.AllowMissingKeys(false)
+
+	return p, nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/config"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestCandidateLines(t *testing.T) {
+	pod := &resource.Info{
+		Mapping: &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}},
+		Object: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":        "web",
+				"annotations": map[string]interface{}{"note": "first\nsecond"},
+			},
+			"status": map[string]interface{}{"phase": "Pending"},
+		}},
+	}
+
+	finder := config.Finder{Lines: []config.Line{
+		{Kinds: []string{"Deployment.apps"}, Template: "{{.metadata.name}} deployment"},
+		{Kinds: []string{"Pod"}, Template: "jsonpath={.metadata.name} {.status.phase}"},
+	}}
+
+	tests := []struct {
+		name     string
+		template string
+		finder   config.Finder
+		want     string
+		wantErr  bool
+	}{
+		{name: "no template"},
+		{name: "go template", template: "{{.metadata.name}} {{.status.phase}}", want: "web Pending"},
+		{name: "go template prefix", template: "go-template={{.metadata.name}}", want: "web"},
+		{name: "jsonpath", template: "jsonpath={.metadata.name} {.status.phase}", want: "web Pending"},
+		{name: "multiple lines joined", template: "{{.metadata.annotations.note}}", want: "first second"},
+		{name: "go template missing field falls back to the default line", template: "{{.metadata.name}} {{.spec.nodeName}}"},
+		{name: "jsonpath missing field rendered empty", template: "jsonpath={.metadata.name}:{.spec.nodeName}", want: "web:"},
+		{name: "config template of the kind", finder: finder, want: "web Pending"},
+		{name: "template overrides config", template: "{{.metadata.name}}", finder: finder, want: "web"},
+		{name: "no config template of the kind", finder: config.Finder{Lines: finder.Lines[:1]}},
+		{name: "invalid go template", template: "{{.metadata.name", wantErr: true},
+		{name: "invalid jsonpath", template: "jsonpath={.metadata.name", wantErr: true},
+		{
+			name:    "invalid config template",
+			finder:  config.Finder{Lines: []config.Line{{Template: "{{.metadata.name"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := candidateLines(tt.template, tt.finder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("candidateLines() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got := lines(pod); got != tt.want {
+				t.Errorf("line = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
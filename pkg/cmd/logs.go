@@ -1,23 +1,27 @@
 package cmd
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"time"
 
 	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
 	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/logs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
 	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/scheme"
 )
 
@@ -25,9 +29,22 @@ const (
 	exampleLogs = `
 	# Selecting a Pod with the fuzzy finder and view the log
 	kubectl fuzzy logs [flags]
+
+	# Show the logs of the previous instance first if the container is crash-looping
+	kubectl fuzzy logs --previous=auto [flags]
+
+	# Pretty-print JSON logs, showing only warnings and errors
+	kubectl fuzzy logs --format=json --fields=ts,level,msg --level=warn [flags]
+
+	# Save the logs to <dir>/<namespace>/<pod>/<container>.log.gz and print them
+	kubectl fuzzy logs --output-dir=<dir> --gzip --tee [flags]
 `
 )
 
+const (
+	previousAuto = "auto"
+)
+
 // NewCmdLogs provides a cobra command wrapping LogsOptions.
 func NewCmdLogs(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
 	o := NewLogsOptions(config, streams)
@@ -60,25 +77,33 @@ func NewCmdLogs(config *genericclioptions.ConfigFlags, streams genericclioptions
 // the current context on a user's KUBECONFIG.
 type LogsOptions struct {
 	configFlags *genericclioptions.ConfigFlags
-	printFlags  *genericclioptions.JSONYamlPrintFlags
 	genericclioptions.IOStreams
 
 	allNamespaces bool
 	namespace     string
 	follow        bool
-	previous      bool
+	previous      string
 	since         time.Duration
 	sinceTime     string
 	timestamps    bool
 	tailLines     int64
 	limitBytes    int64
 
+	format     string
+	fields     []string
+	grep       string
+	level      string
+	logOptions []logs.Option
+
+	outputDir string
+	gzip      bool
+	tee       bool
+
 	podClient coreclient.PodsGetter
 	builder   *resource.Builder
 
-	preview       bool
-	previewFormat string
-	rawPreview    bool
+	finder  finderFlags
+	preview previewFlags
 }
 
 // AddFlags adds a flag to the flag set.
@@ -89,8 +114,11 @@ func (o *LogsOptions) AddFlags(flags *pflag.FlagSet) {
 			"Namespace in current context is ignored even if specified with --namespace.")
 	flags.BoolVarP(&o.follow, "follow", "f", false,
 		"Specify if the logs should be streamed.")
-	flags.BoolVarP(&o.previous, "previous", "p", false,
-		"If true, print the logs for the previous instance of the container in a pod if it exists.")
+	flags.StringVarP(&o.previous, "previous", "p", "false",
+		"If true, print the logs for the previous instance of the container in a pod if it exists. "+
+			"If auto, print the previous instance first when the container restarted and "+
+			"the current instance has no output yet. One of true|false|auto.")
+	flags.Lookup("previous").NoOptDefVal = "true"
 	flags.DurationVar(&o.since, "since", time.Second*0,
 		"Only return logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs. "+
 			"Only one of since-time / since may be used.")
@@ -106,20 +134,36 @@ func (o *LogsOptions) AddFlags(flags *pflag.FlagSet) {
 		"Maximum bytes of logs to return. Defaults to no limit.")
 
 	// original flags
-	flags.BoolVarP(&o.preview, "preview", "P", false,
-		"If true, display the object YAML|JSON by preview window for fuzzy finder selector.")
-	flags.StringVar(&o.previewFormat, "preview-format", "yaml",
-		"Preview window output format. One of json|yaml.")
-	flags.BoolVar(&o.rawPreview, "raw-preview", false,
-		"If true, display the unsimplified object in the preview window. (default is simplified)")
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
+	flags.StringVar(&o.format, "format", logs.FormatRaw,
+		"Log line format used for pretty-printing and filtering. Lines not in the format are printed unchanged. "+
+			"One of json|logfmt|raw.")
+	flags.StringSliceVar(&o.fields, "fields", nil,
+		"Comma separated list of fields of structured log lines to print (e.g. --fields=ts,level,msg). "+
+			"Defaults to all fields.")
+	flags.StringVar(&o.grep, "grep", "",
+		"Only print log lines matching the regular expression.")
+	flags.StringVar(&o.level, "level", "",
+		"Only print structured log lines at or above the level (e.g. debug, info, warn, error). "+
+			"Lines without a level are always printed.")
+	flags.StringVar(&o.outputDir, "output-dir", "",
+		"If present, write the logs to <output-dir>/<namespace>/<pod>/<container>.log instead of stdout. "+
+			"The logs of the previous instance are written to <container>.previous.log if they are still available.")
+	flags.BoolVar(&o.gzip, "gzip", false,
+		"If true, compress the log files written to --output-dir with gzip.")
+	flags.BoolVar(&o.tee, "tee", false,
+		"If true, also print the logs to stdout when --output-dir is specified.")
 }
 
 // NewLogsOptions provides an instance of LogsOptions with default values.
 func NewLogsOptions(flags *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *LogsOptions {
 	return &LogsOptions{
 		configFlags: flags,
-		printFlags:  genericclioptions.NewJSONYamlPrintFlags(),
-		IOStreams:   streams,
+		preview: previewFlags{
+			colorUsage: "Colorize level fields of structured log lines and the preview window. One of auto|always|never.",
+		},
+		IOStreams: streams,
 	}
 }
 
@@ -130,13 +174,28 @@ func (o *LogsOptions) Complete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to new Kubernetes client: %w", err)
 	}
 
-	if !o.preview {
-		o.preview, _ = strconv.ParseBool(os.Getenv(previewEnabledEnvVar))
-	}
+	o.preview.Complete()
 
 	o.podClient = client.CoreV1()
 	o.builder = resource.NewBuilder(o.configFlags)
 
+	o.logOptions = []logs.Option{
+		logs.WithFormat(o.format),
+		logs.WithFields(o.fields),
+		logs.WithLevel(o.level),
+		// escape sequences must not be written to log files
+		logs.WithColor(o.outputDir == "" && useColor(o.preview.color, o.Out)),
+	}
+
+	if o.grep != "" {
+		re, err := regexp.Compile(o.grep)
+		if err != nil {
+			return fmt.Errorf("invalid --grep expression: %w", err)
+		}
+
+		o.logOptions = append(o.logOptions, logs.WithGrep(re))
+	}
+
 	if !o.allNamespaces {
 		kubeConfig := o.configFlags.ToRawKubeConfigLoader()
 
@@ -152,7 +211,43 @@ func (o *LogsOptions) Complete(cmd *cobra.Command, args []string) error {
 }
 
 // Validate ensures that all required arguments and flag values are provided.
-func (LogsOptions) Validate() error {
+func (o *LogsOptions) Validate() error {
+	if o.previous != previousAuto {
+		if _, err := strconv.ParseBool(o.previous); err != nil {
+			return fmt.Errorf("invalid --previous value %q, must be one of true|false|auto", o.previous)
+		}
+	}
+
+	if !logs.ValidFormat(o.format) {
+		return fmt.Errorf("invalid --format value %q, must be one of json|logfmt|raw", o.format)
+	}
+
+	if o.level != "" {
+		if o.format == logs.FormatRaw {
+			return fmt.Errorf("--level requires --format=json or --format=logfmt")
+		}
+
+		if !logs.ValidLevel(o.level) {
+			return fmt.Errorf("unknown --level value %q", o.level)
+		}
+	}
+
+	if len(o.fields) > 0 && o.format == logs.FormatRaw {
+		return fmt.Errorf("--fields requires --format=json or --format=logfmt")
+	}
+
+	if o.outputDir == "" && (o.gzip || o.tee) {
+		return fmt.Errorf("--gzip and --tee require --output-dir")
+	}
+
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.finder.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -179,22 +274,27 @@ func (o *LogsOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("resource not found")
 	}
 
-	var printer printers.ResourcePrinter
-	if o.preview {
-		printer, err = o.printFlags.ToPrinter(o.previewFormat)
-		if err != nil {
-			return err
-		}
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
+	if err != nil {
+		return err
 	}
 
-	info, err := fuzzyfinder.Infos(infos,
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
+	if err != nil {
+		return err
+	}
+
+	finderOpts := append(previewOpts,
 		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
-		fuzzyfinder.WithPreview(printer),
-		fuzzyfinder.WithRawPreview(o.rawPreview))
+		fuzzyfinder.WithScore(hist.score))
+
+	info, err := fuzzyfinder.Infos(infos, append(finderOpts, o.finder.options(o.ErrOut)...)...)
 	if err != nil {
 		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
 	}
 
+	hist.record(info)
+
 	uncastVersionedObj, err := scheme.Scheme.ConvertToVersion(info.Object, corev1.SchemeGroupVersion)
 	if err != nil {
 		return fmt.Errorf("failed to convert object: %w", err)
@@ -208,7 +308,11 @@ func (o *LogsOptions) Run(ctx context.Context) error {
 	var containerName string
 
 	if len(pod.Spec.Containers) > 1 {
-		container, err := fuzzyfinder.Containers(pod.Spec.Containers)
+		container, err := fuzzyfinder.Containers(pod.Spec.Containers,
+			fuzzyfinder.WithSelectOne(o.finder.selectOne),
+			fuzzyfinder.WithExitZero(o.finder.exitZero),
+			fuzzyfinder.WithPick(o.finder.pick),
+			fuzzyfinder.WithErrOut(o.ErrOut))
 		if err != nil {
 			return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
 		}
@@ -218,10 +322,180 @@ func (o *LogsOptions) Run(ctx context.Context) error {
 		containerName = pod.Spec.Containers[0].Name
 	}
 
+	if o.outputDir != "" {
+		return o.saveLogs(ctx, pod, containerName)
+	}
+
+	if o.previous == previousAuto {
+		return o.autoPreviousLogs(ctx, pod, containerName)
+	}
+
+	previous, _ := strconv.ParseBool(o.previous)
+
+	return o.streamLogs(ctx, pod, containerName, previous, o.Out)
+}
+
+// autoPreviousLogs prints the logs of the previous instance of the container followed by the current one
+// when the container has restarted and the current instance has not produced any output yet.
+func (o *LogsOptions) autoPreviousLogs(ctx context.Context, pod *corev1.Pod, containerName string) error {
+	status := containerStatus(pod, containerName)
+	if status == nil || status.RestartCount == 0 || status.LastTerminationState.Terminated == nil {
+		return o.streamLogs(ctx, pod, containerName, false, o.Out)
+	}
+
+	empty, err := o.currentLogsEmpty(ctx, pod, containerName)
+	if err != nil {
+		return err
+	}
+
+	if !empty {
+		return o.streamLogs(ctx, pod, containerName, false, o.Out)
+	}
+
+	terminated := status.LastTerminationState.Terminated
+
+	_, _ = fmt.Fprintf(o.Out, "==> previous instance of container %q (reason: %s, exit code: %d, finished at: %s) <==\n",
+		containerName, terminated.Reason, terminated.ExitCode, terminated.FinishedAt.Format(time.RFC3339))
+
+	if err := o.streamLogs(ctx, pod, containerName, true, o.Out); err != nil {
+		return err
+	}
+
+	if status.State.Waiting != nil {
+		_, _ = fmt.Fprintf(o.Out, "==> current instance of container %q is waiting (reason: %s, restarts: %d) <==\n",
+			containerName, status.State.Waiting.Reason, status.RestartCount)
+
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(o.Out, "==> current instance of container %q (restarts: %d) <==\n",
+		containerName, status.RestartCount)
+
+	return o.streamLogs(ctx, pod, containerName, false, o.Out)
+}
+
+// saveLogs writes the logs of the container to files under the output directory.
+// The logs of the previous instance are written to a separate file if the container has restarted
+// and they are available. The kubelet may have removed them, which is reported as a warning.
+func (o *LogsOptions) saveLogs(ctx context.Context, pod *corev1.Pod, containerName string) error {
+	dir := filepath.Join(o.outputDir, pod.Namespace, pod.Name)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	previous, _ := strconv.ParseBool(o.previous)
+
+	switch status := containerStatus(pod, containerName); {
+	case status != nil && status.LastTerminationState.Terminated != nil:
+		o.savePreviousLogs(ctx, pod, status, filepath.Join(dir, containerName+".previous.log"))
+	case previous:
+		_, _ = fmt.Fprintf(o.ErrOut, "warning: container %q has no previous instance, no logs written\n",
+			containerName)
+	}
+
+	if previous {
+		return nil
+	}
+
+	return o.writeLogFile(ctx, pod, containerName, false, filepath.Join(dir, containerName+".log"))
+}
+
+// savePreviousLogs writes the logs of the previous instance of the container to the file.
+// A failure is reported as a warning and the file is skipped so that the current logs are still saved.
+func (o *LogsOptions) savePreviousLogs(ctx context.Context, pod *corev1.Pod, status *corev1.ContainerStatus,
+	path string) {
+	terminated := status.LastTerminationState.Terminated
+
+	if o.tee {
+		_, _ = fmt.Fprintf(o.Out, "==> previous instance of container %q (reason: %s, exit code: %d) <==\n",
+			status.Name, terminated.Reason, terminated.ExitCode)
+	}
+
+	if err := o.writeLogFile(ctx, pod, status.Name, true, path); err != nil {
+		_, _ = fmt.Fprintf(o.ErrOut, "warning: skipped the logs of the previous instance of container %q: %v\n",
+			status.Name, err)
+	}
+
+	if o.tee {
+		_, _ = fmt.Fprintf(o.Out, "==> current instance of container %q <==\n", status.Name)
+	}
+}
+
+// writeLogFile writes the logs of the container to the file, compressing them if gzip is enabled.
+// The file is removed if the logs cannot be written.
+func (o *LogsOptions) writeLogFile(ctx context.Context, pod *corev1.Pod, containerName string,
+	previous bool, path string) (err error) {
+	if o.gzip {
+		path += ".gz"
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("failed to close log file: %w", cerr)
+		}
+
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	var w io.Writer = f
+
+	if o.gzip {
+		gw := gzip.NewWriter(f)
+		defer func() {
+			if cerr := gw.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("failed to compress log file: %w", cerr)
+			}
+		}()
+
+		w = gw
+	}
+
+	if o.tee {
+		w = io.MultiWriter(w, o.Out)
+	}
+
+	if err := o.streamLogs(ctx, pod, containerName, previous, w); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(o.ErrOut, "wrote logs to %s\n", path)
+
+	return nil
+}
+
+// currentLogsEmpty reports whether the current instance of the container has not produced any output.
+// A container that cannot serve logs yet (e.g. waiting to start) is regarded as empty.
+func (o *LogsOptions) currentLogsEmpty(ctx context.Context, pod *corev1.Pod, containerName string) (bool, error) {
+	limitBytes := int64(1)
+
+	req := o.podClient.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  containerName,
+		LimitBytes: &limitBytes,
+	})
+
+	b, err := req.DoRaw(ctx)
+	if err != nil {
+		klog.V(1).Info(err)
+
+		return true, nil
+	}
+
+	return len(b) == 0, nil
+}
+
+func (o *LogsOptions) streamLogs(ctx context.Context, pod *corev1.Pod, containerName string,
+	previous bool, out io.Writer) error {
 	req := o.podClient.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
 		Container:    containerName,
 		Follow:       o.follow,
-		Previous:     o.previous,
+		Previous:     previous,
 		SinceSeconds: o.ConvertSinceSeconds(),
 		SinceTime:    o.ConvertSinceTime(),
 		Timestamps:   o.timestamps,
@@ -235,10 +509,22 @@ func (o *LogsOptions) Run(ctx context.Context) error {
 	}
 	defer func() { _ = reader.Close() }()
 
-	if _, err = io.Copy(o.Out, reader); err != nil {
+	w := logs.NewWriter(out, o.logOptions...)
+
+	if _, err = io.Copy(w, reader); err != nil {
 		return err
 	}
 
+	return w.Flush()
+}
+
+func containerStatus(pod *corev1.Pod, containerName string) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == containerName {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+
 	return nil
 }
 
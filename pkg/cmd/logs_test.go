@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+func TestSaveLogs(t *testing.T) {
+	restarted := corev1.ContainerStatus{
+		Name:                 "app",
+		RestartCount:         1,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+	}
+
+	tests := []struct {
+		name     string
+		status   *corev1.ContainerStatus
+		previous string
+		// previousGone makes the API server fail the request of the previous logs.
+		previousGone bool
+		// want are the contents of the written files by name.
+		want        map[string]string
+		wantWarning string
+	}{
+		{
+			name: "not restarted",
+			want: map[string]string{"app.log": "current\n"},
+		},
+		{
+			name:   "restarted",
+			status: &restarted,
+			want:   map[string]string{"app.log": "current\n", "app.previous.log": "previous\n"},
+		},
+		{
+			name:         "previous logs removed by the kubelet",
+			status:       &restarted,
+			previousGone: true,
+			want:         map[string]string{"app.log": "current\n"},
+			wantWarning:  `warning: skipped the logs of the previous instance of container "app"`,
+		},
+		{
+			name:     "previous only",
+			status:   &restarted,
+			previous: "true",
+			want:     map[string]string{"app.previous.log": "previous\n"},
+		},
+		{
+			name:        "previous without previous instance",
+			previous:    "true",
+			want:        map[string]string{},
+			wantWarning: `warning: container "app" has no previous instance, no logs written`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := "previous\n"
+			if tt.previousGone {
+				previous = ""
+			}
+
+			client := logsClient(t, "current\n", previous)
+
+			pod := &corev1.Pod{}
+			pod.Namespace, pod.Name = "demo", "web"
+
+			if tt.status != nil {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{*tt.status}
+			}
+
+			streams, _, _, errOut := genericclioptions.NewTestIOStreams()
+			o := &LogsOptions{
+				IOStreams: streams,
+				outputDir: t.TempDir(),
+				previous:  tt.previous,
+				podClient: client,
+			}
+
+			if err := o.saveLogs(context.Background(), pod, "app"); err != nil {
+				t.Fatalf("saveLogs() error = %v", err)
+			}
+
+			dir := filepath.Join(o.outputDir, "demo", "web")
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]string, len(entries))
+
+			for _, e := range entries {
+				b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got[e.Name()] = string(b)
+			}
+
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+
+			if !strings.Contains(errOut.String(), tt.wantWarning) || tt.wantWarning == "" && strings.Contains(errOut.String(), "warning:") {
+				t.Errorf("errOut = %q, want warning %q", errOut.String(), tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestAutoPreviousLogs(t *testing.T) {
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		Reason:     "Error",
+		ExitCode:   1,
+		FinishedAt: metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}}
+
+	tests := []struct {
+		name    string
+		status  *corev1.ContainerStatus
+		current string
+		want    string
+	}{
+		{
+			name:    "no status",
+			current: "current\n",
+			want:    "current\n",
+		},
+		{
+			name:    "not restarted",
+			status:  &corev1.ContainerStatus{Name: "app"},
+			current: "current\n",
+			want:    "current\n",
+		},
+		{
+			name:    "restarted with current output",
+			status:  &corev1.ContainerStatus{Name: "app", RestartCount: 1, LastTerminationState: terminated},
+			current: "current\n",
+			want:    "current\n",
+		},
+		{
+			name:   "restarted without current output",
+			status: &corev1.ContainerStatus{Name: "app", RestartCount: 2, LastTerminationState: terminated},
+			want: `==> previous instance of container "app" (reason: Error, exit code: 1, finished at: 2024-01-02T03:04:05Z) <==
+previous
+==> current instance of container "app" (restarts: 2) <==
+`,
+		},
+		{
+			name: "restarted and waiting",
+			status: &corev1.ContainerStatus{
+				Name:                 "app",
+				RestartCount:         3,
+				LastTerminationState: terminated,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			},
+			want: `==> previous instance of container "app" (reason: Error, exit code: 1, finished at: 2024-01-02T03:04:05Z) <==
+previous
+==> current instance of container "app" is waiting (reason: CrashLoopBackOff, restarts: 3) <==
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{}
+			pod.Namespace, pod.Name = "demo", "web"
+
+			if tt.status != nil {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{*tt.status}
+			}
+
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := &LogsOptions{
+				IOStreams: streams,
+				podClient: logsClient(t, tt.current, "previous\n"),
+			}
+
+			if err := o.autoPreviousLogs(context.Background(), pod, "app"); err != nil {
+				t.Fatalf("autoPreviousLogs() error = %v", err)
+			}
+
+			if out.String() != tt.want {
+				t.Errorf("autoPreviousLogs() output =\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+// logsClient returns the client of an API server serving the logs of the current and the previous instance.
+// The request of the previous logs fails if previous is empty, as if the kubelet has removed them.
+func logsClient(t *testing.T, current, previous string) coreclient.PodsGetter {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("previous") != "true" {
+			_, _ = w.Write([]byte(current))
+
+			return
+		}
+
+		if previous == "" {
+			http.Error(w, `previous terminated container "app" not found`, http.StatusBadRequest)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(previous))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := coreclient.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
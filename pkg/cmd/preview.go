@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/config"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	fuzzyprinters "github.com/d-kuro/kubectl-fuzzy/pkg/printers"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	previewFormatTree = "tree"
+	previewFormatDiff = "diff"
+)
+
+// previewPrinter returns the printer of the preview window for the format and
+// whether the printer requires the unsimplified object.
+// color colorizes the output of the diff format.
+func previewPrinter(ctx context.Context, configFlags *genericclioptions.ConfigFlags,
+	format string, color bool) (printers.ResourcePrinter, bool, error) {
+	switch format {
+	case previewFormatTree:
+		printer, err := newTreePrinter(ctx, configFlags)
+
+		return printer, true, err
+	case previewFormatDiff:
+		printer, err := newDiffPrinter(ctx, configFlags, color)
+
+		return printer, true, err
+	}
+
+	printer, err := genericclioptions.NewJSONYamlPrintFlags().ToPrinter(format)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get printer: %w", err)
+	}
+
+	return printer, false, nil
+}
+
+// newTreePrinter returns a printer that prints the dependency tree of the object.
+func newTreePrinter(ctx context.Context, configFlags *genericclioptions.ConfigFlags) (*fuzzyprinters.Tree, error) {
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("faild to get REST config: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("faild to create dynamic client: %w", err)
+	}
+
+	discoveryClient, err := configFlags.ToDiscoveryClient()
+	if err != nil {
+		return nil, fmt.Errorf("faild to create discovery client: %w", err)
+	}
+
+	return &fuzzyprinters.Tree{
+		NewGraph: func(namespace string) (*kubernetes.OwnerGraph, error) {
+			return kubernetes.NewOwnerGraph(ctx, discoveryClient, dynamicClient, namespace)
+		},
+	}, nil
+}
+
+// newDiffPrinter returns a printer that prints the diffs against the last-applied-configuration annotation
+// and the previous revision of the object.
+func newDiffPrinter(ctx context.Context, configFlags *genericclioptions.ConfigFlags,
+	color bool) (*fuzzyprinters.Diff, error) {
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("faild to get REST config: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("faild to create dynamic client: %w", err)
+	}
+
+	return &fuzzyprinters.Diff{
+		Revisions: func(obj *unstructured.Unstructured) ([]kubernetes.Revision, error) {
+			return kubernetes.Revisions(ctx, dynamicClient, obj)
+		},
+		Color: color,
+	}, nil
+}
+
+// previewFlags provides the flags controlling the preview window and the candidate lines of the fuzzy finder.
+type previewFlags struct {
+	// objectFormats enables the tree and diff preview formats.
+	objectFormats bool
+	// always displays the preview window without --preview.
+	always bool
+	// colorUsage replaces the usage of --color if not empty.
+	colorUsage string
+	// wrapPrinter, if not nil, wraps the printer of the preview window after the simplification.
+	wrapPrinter func(printers.ResourcePrinter) printers.ResourcePrinter
+	// defaultLine, if not nil, renders the candidate line of the info without a line template.
+	defaultLine func(info *resource.Info) string
+
+	preview       bool
+	previewFormat string
+	rawPreview    bool
+	color         string
+	colorTheme    string
+	lineTemplate  string
+}
+
+// AddFlags adds a flag to the flag set.
+func (f *previewFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.lineTemplate, "line-template", "",
+		"Template of the fuzzy finder candidate lines. Go template or jsonpath=TEMPLATE over the object "+
+			"(e.g. '{{.metadata.name}} {{.status.phase}}'). Defaults to the template configured for the kind.")
+
+	if !f.always {
+		flags.BoolVarP(&f.preview, "preview", "P", false,
+			"If true, display the object YAML|JSON by preview window for fuzzy finder selector.")
+	}
+
+	formatUsage := "Preview window output format. One of " + strings.Join(f.formats(), "|") + "."
+	if f.objectFormats {
+		formatUsage += " tree shows the dependents of the object found by following the owner references. " +
+			"diff shows the changes from the last-applied-configuration annotation and the previous revision."
+	}
+
+	flags.StringVar(&f.previewFormat, "preview-format", "yaml", formatUsage)
+	flags.BoolVar(&f.rawPreview, "raw-preview", false,
+		"If true, display the unsimplified object in the preview window. (default is simplified)")
+
+	colorUsage := f.colorUsage
+	if colorUsage == "" {
+		colorUsage = "Colorize the preview window. One of auto|always|never. auto disables colors if NO_COLOR is set."
+	}
+
+	flags.StringVar(&f.color, "color", colorAuto, colorUsage)
+	flags.StringVar(&f.colorTheme, "color-theme", fuzzyprinters.ThemeDefault,
+		"Color theme of the preview window. One of "+strings.Join(fuzzyprinters.ThemeNames(), "|")+".")
+}
+
+// Complete enables the preview window by the environment variable if --preview is not specified.
+func (f *previewFlags) Complete() {
+	if f.always {
+		f.preview = true
+
+		return
+	}
+
+	if !f.preview {
+		f.preview, _ = strconv.ParseBool(os.Getenv(previewEnabledEnvVar))
+	}
+}
+
+// Validate ensures that the --preview-format, --color and --color-theme values are valid.
+func (f *previewFlags) Validate() error {
+	if err := validateColor(f.color, f.colorTheme); err != nil {
+		return err
+	}
+
+	for _, format := range f.formats() {
+		if f.previewFormat == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid --preview-format value %q, must be one of %s",
+		f.previewFormat, strings.Join(f.formats(), "|"))
+}
+
+func (f *previewFlags) formats() []string {
+	if f.objectFormats {
+		return []string{"json", "yaml", previewFormatTree, previewFormatDiff}
+	}
+
+	return []string{"json", "yaml"}
+}
+
+// finderOptions returns the fuzzy finder options displaying the preview window and rendering the candidate lines.
+// The config file is loaded once for the simplification rules and the line templates.
+func (f *previewFlags) finderOptions(ctx context.Context,
+	configFlags *genericclioptions.ConfigFlags) ([]fuzzyfinder.Option, error) {
+	c, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var (
+		printer    printers.ResourcePrinter
+		rules      fuzzyprinters.Rules
+		rawPreview = f.rawPreview
+		theme      = previewTheme(f.color, f.colorTheme)
+	)
+
+	if f.preview {
+		rules, err = c.Preview.Simplify.ToRules()
+		if err != nil {
+			return nil, err
+		}
+
+		var raw bool
+
+		printer, raw, err = previewPrinter(ctx, configFlags, f.previewFormat, theme != nil)
+		if err != nil {
+			return nil, err
+		}
+
+		rawPreview = rawPreview || raw
+
+		if f.wrapPrinter != nil {
+			if !rawPreview {
+				printer, rawPreview = &fuzzyprinters.Simplify{Delegate: printer, Rules: rules}, true
+			}
+
+			printer = f.wrapPrinter(printer)
+		}
+	}
+
+	lines, err := candidateLines(f.lineTemplate, c.Finder)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.defaultLine != nil {
+		templateLines := lines
+
+		lines = func(info *resource.Info) string {
+			if line := templateLines(info); line != "" {
+				return line
+			}
+
+			return f.defaultLine(info)
+		}
+	}
+
+	return []fuzzyfinder.Option{
+		fuzzyfinder.WithPreview(printer),
+		fuzzyfinder.WithRawPreview(rawPreview),
+		fuzzyfinder.WithSimplifyRules(rules),
+		fuzzyfinder.WithHighlight(theme),
+		fuzzyfinder.WithLine(lines),
+	}, nil
+}
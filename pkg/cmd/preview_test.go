@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestPreviewFlagsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       previewFlags
+		wantErr bool
+	}{
+		{name: "yaml", f: previewFlags{previewFormat: "yaml"}},
+		{name: "json", f: previewFlags{previewFormat: "json"}},
+		{name: "tree", f: previewFlags{previewFormat: previewFormatTree, objectFormats: true}},
+		{name: "diff", f: previewFlags{previewFormat: previewFormatDiff, objectFormats: true}},
+		{name: "tree without object formats", f: previewFlags{previewFormat: previewFormatTree}, wantErr: true},
+		{name: "unknown format", f: previewFlags{previewFormat: "wide", objectFormats: true}, wantErr: true},
+		{name: "invalid color", f: previewFlags{previewFormat: "yaml", color: "sometimes"}, wantErr: true},
+		{name: "invalid color theme", f: previewFlags{previewFormat: "yaml", colorTheme: "unknown"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.f.color == "" {
+				tt.f.color = colorAuto
+			}
+
+			if tt.f.colorTheme == "" {
+				tt.f.colorTheme = "default"
+			}
+
+			if err := tt.f.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreviewFlagsComplete(t *testing.T) {
+	t.Setenv(previewEnabledEnvVar, "true")
+
+	f := previewFlags{}
+	f.Complete()
+
+	if !f.preview {
+		t.Errorf("preview = false, want true with %s=true", previewEnabledEnvVar)
+	}
+
+	t.Setenv(previewEnabledEnvVar, "false")
+
+	f = previewFlags{always: true}
+	f.Complete()
+
+	if !f.preview {
+		t.Errorf("preview = false, want true if always")
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/asciicast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const (
+	exampleReplay = `
+	# Play back a session recorded with "kubectl fuzzy exec --record"
+	kubectl fuzzy replay FILE [flags]
+`
+)
+
+// NewCmdReplay provides a cobra command wrapping ReplayOptions.
+func NewCmdReplay(streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewReplayOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:           "replay FILE",
+		Short:         "Play back a recorded session",
+		Example:       exampleReplay,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			return o.Run(c.Context())
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// ReplayOptions provides information required to play back a recorded session.
+type ReplayOptions struct {
+	genericclioptions.IOStreams
+
+	file          string
+	speed         float64
+	idleTimeLimit time.Duration
+}
+
+// NewReplayOptions provides an instance of ReplayOptions with default values.
+func NewReplayOptions(streams genericclioptions.IOStreams) *ReplayOptions {
+	return &ReplayOptions{
+		IOStreams: streams,
+	}
+}
+
+// AddFlags adds a flag to the flag set.
+func (o *ReplayOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.Float64Var(&o.speed, "speed", 1,
+		"Playback speed multiplier.")
+	flags.DurationVar(&o.idleTimeLimit, "idle-time-limit", 0,
+		"Maximum time to wait between outputs, e.g. 2s. Zero means no limit.")
+}
+
+// Complete sets all information required for play back.
+func (o *ReplayOptions) Complete(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("exactly one recording file must be specified")
+	}
+
+	o.file = args[0]
+
+	return nil
+}
+
+// Validate ensures that all required arguments and flag values are provided.
+func (o *ReplayOptions) Validate() error {
+	if o.speed <= 0 {
+		return fmt.Errorf("--speed must be greater than 0")
+	}
+
+	return nil
+}
+
+// Run plays back the recorded session.
+func (o *ReplayOptions) Run(ctx context.Context) error {
+	f, err := os.Open(o.file)
+	if err != nil {
+		return fmt.Errorf("failed to open recording file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return asciicast.Play(ctx, f, o.Out,
+		asciicast.WithSpeed(o.speed),
+		asciicast.WithIdleTimeLimit(o.idleTimeLimit))
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// apiResources returns the resource types served by the API server, including the custom resources,
+// supporting all the verbs.
+func apiResources(configFlags *genericclioptions.ConfigFlags, verbs ...string) ([]kubernetes.APIResource, error) {
+	discoveryClient, err := configFlags.ToDiscoveryClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	all, err := kubernetes.APIResources(discoveryClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover resource types: %w", err)
+	}
+
+	var resources []kubernetes.APIResource
+
+	for i := range all {
+		if all[i].Supports(verbs...) {
+			resources = append(resources, all[i])
+		}
+	}
+
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no resource types support %s", strings.Join(verbs, ", "))
+	}
+
+	return resources, nil
+}
+
+// selectResourceType selects a resource type supporting all the verbs with the fuzzy finder
+// and returns its name accepted as TYPE (e.g. deployments.apps).
+// The finder options are the ones of the finder flags for the levels before the object.
+func selectResourceType(configFlags *genericclioptions.ConfigFlags, finderOpts []fuzzyfinder.Option,
+	verbs ...string) (string, error) {
+	resources, err := apiResources(configFlags, verbs...)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := selectAPIResource(resources,
+		append(finderOpts, fuzzyfinder.WithHeader("resource type supporting "+strings.Join(verbs, ", ")))...)
+	if err != nil {
+		return "", fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	return r.Name(), nil
+}
+
+// selectAPIResource selects a resource type with the fuzzy finder.
+func selectAPIResource(resources []kubernetes.APIResource, opts ...fuzzyfinder.Option) (kubernetes.APIResource, error) {
+	idx, err := fuzzyfinder.Lines(apiResourceLines(resources), opts...)
+	if err != nil {
+		return kubernetes.APIResource{}, err
+	}
+
+	return resources[idx], nil
+}
+
+// apiResourceLines returns the aligned lines showing the name, the short names, the scope, the kind
+// and the supported verbs of each resource type.
+func apiResourceLines(resources []kubernetes.APIResource) []string {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	w := printers.GetNewTabWriter(&buf)
+
+	for i := range resources {
+		r := &resources[i]
+
+		shortNames := strings.Join(r.ShortNames, ",")
+		if shortNames == "" {
+			shortNames = "-"
+		}
+
+		scope := "cluster"
+		if r.Namespaced {
+			scope = "namespaced"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t[%s]\n",
+			r.Name(), shortNames, scope, r.Kind, strings.Join(r.Verbs, " "))
+	}
+
+	_ = w.Flush()
+
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAPIResourceLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []kubernetes.APIResource
+		want      []string
+	}{
+		{name: "no resources", want: nil},
+		{
+			name: "aligned",
+			resources: []kubernetes.APIResource{
+				{
+					GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+					Kind:                 "Pod",
+					ShortNames:           []string{"po"},
+					Namespaced:           true,
+					Verbs:                []string{"get", "list", "delete"},
+				},
+				{
+					GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+					Kind:                 "Deployment",
+					ShortNames:           []string{"deploy"},
+					Namespaced:           true,
+					Verbs:                []string{"get", "list"},
+				},
+				{
+					GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "componentstatuses"},
+					Kind:                 "ComponentStatus",
+					Verbs:                []string{"get", "list"},
+				},
+			},
+			want: []string{
+				"pods                po       namespaced   Pod               [get list delete]",
+				"deployments.apps    deploy   namespaced   Deployment        [get list]",
+				"componentstatuses   -        cluster      ComponentStatus   [get list]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := apiResourceLines(tt.resources); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apiResourceLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
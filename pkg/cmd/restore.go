@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/backup"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+const (
+	exampleRestore = `
+	# Selecting a backup saved by "kubectl fuzzy delete --backup" with the fuzzy finder and re-create the object
+	kubectl fuzzy restore [flags]
+
+	# Re-create the object together with its dependents saved in the backup
+	kubectl fuzzy restore --with-dependents [flags]
+`
+)
+
+// NewCmdRestore provides a cobra command wrapping RestoreOptions.
+func NewCmdRestore(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewRestoreOptions(config, streams)
+
+	cmd := &cobra.Command{
+		Use:           "restore",
+		Short:         "Selecting a backup with the fuzzy finder and re-create the deleted object",
+		Example:       exampleRestore,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			return o.Run(c.Context())
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// RestoreOptions provides information required to re-create objects from backups.
+type RestoreOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	printFlags  *genericclioptions.PrintFlags
+	genericclioptions.IOStreams
+
+	printObj func(obj runtime.Object) error
+
+	backupDir      string
+	allContexts    bool
+	withDependents bool
+
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+	context       string
+	namespace     string
+}
+
+// NewRestoreOptions provides an instance of RestoreOptions with default values.
+func NewRestoreOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *RestoreOptions {
+	return &RestoreOptions{
+		configFlags: config,
+		printFlags:  genericclioptions.NewPrintFlags("restored").WithTypeSetter(scheme.Scheme),
+		IOStreams:   streams,
+	}
+}
+
+// AddFlags adds a flag to the flag set.
+func (o *RestoreOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.backupDir, "backup-dir", backup.DefaultDir(),
+		"Directory where backups are saved.")
+	flags.BoolVar(&o.allContexts, "all-contexts", false,
+		"If true, list the backups of all contexts. By default, only the backups of the current context are listed.")
+	flags.BoolVar(&o.withDependents, "with-dependents", false,
+		"If true, also re-create the dependents saved in the backup. "+
+			"Dependents managed by a controller are usually re-created by the controller itself.")
+}
+
+// Complete sets all information required for restore.
+func (o *RestoreOptions) Complete(cmd *cobra.Command, args []string) error {
+	restConfig, err := o.configFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("faild to get REST config: %w", err)
+	}
+
+	o.dynamicClient, err = dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("faild to create dynamic client: %w", err)
+	}
+
+	o.mapper, err = o.configFlags.ToRESTMapper()
+	if err != nil {
+		return fmt.Errorf("faild to get REST mapper: %w", err)
+	}
+
+	o.context, _, err = kubernetes.CurrentContext(o.configFlags)
+	if err != nil {
+		return fmt.Errorf("failed to get current context: %w", err)
+	}
+
+	o.namespace, _, err = o.configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return fmt.Errorf("faild to get namespace from kube config: %w", err)
+	}
+
+	printer, err := o.printFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+
+	o.printObj = func(obj runtime.Object) error {
+		return printer.PrintObj(obj, o.Out)
+	}
+
+	return nil
+}
+
+// Validate ensures that all required arguments and flag values are provided.
+func (o *RestoreOptions) Validate() error {
+	return nil
+}
+
+// Run execute fizzy finder and re-create the object saved in the selected backup.
+func (o *RestoreOptions) Run(ctx context.Context) error {
+	contextName := o.context
+	if o.allContexts {
+		contextName = ""
+	}
+
+	backups, err := backup.List(o.backupDir, contextName)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	if len(backups) == 0 {
+		return fmt.Errorf("backup not found")
+	}
+
+	b, err := selectBackup(backups)
+	if err != nil {
+		return fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	objs := b.Objects[:1]
+	if o.withDependents {
+		objs = b.Objects
+	}
+
+	// the new UIDs of the restored objects by the saved UIDs, to re-own the restored dependents
+	uids := make(map[types.UID]types.UID, len(objs))
+
+	for _, saved := range objs {
+		obj := backup.Reown(saved, uids)
+		gvk := obj.GroupVersionKind()
+
+		mapping, err := o.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return fmt.Errorf("failed to get REST mapping of %s: %w", gvk, err)
+		}
+
+		var ri dynamic.ResourceInterface = o.dynamicClient.Resource(mapping.Resource)
+
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			namespace := obj.GetNamespace()
+			if namespace == "" {
+				namespace = o.namespace
+			}
+
+			ri = o.dynamicClient.Resource(mapping.Resource).Namespace(namespace)
+		}
+
+		res, err := ri.Create(ctx, obj, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to restore %s %q: %w", gvk.Kind, obj.GetName(), err)
+		}
+
+		uids[saved.GetUID()] = res.GetUID()
+
+		if err := o.printObj(res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// selectBackup selects a backup with the fuzzy finder.
+// The preview window shows the deletion time and the saved objects.
+func selectBackup(backups []*backup.Backup) (*backup.Backup, error) {
+	lines := make([]string, 0, len(backups))
+
+	for _, b := range backups {
+		obj := b.Objects[0]
+
+		line := fmt.Sprintf("%s %s/%s",
+			b.DeletedAt.Local().Format(time.DateTime),
+			strings.ToLower(obj.GroupVersionKind().GroupKind().String()),
+			obj.GetName())
+
+		if len(obj.GetNamespace()) >= 1 {
+			line += fmt.Sprintf(" (%s)", obj.GetNamespace())
+		}
+
+		lines = append(lines, line)
+	}
+
+	idx, err := fuzzyfinder.Lines(lines,
+		fuzzyfinder.WithPreviewFunc(func(i int) string {
+			b, err := os.ReadFile(backups[i].Path)
+			if err != nil {
+				return fmt.Sprintf("error: %s", err)
+			}
+
+			return string(b)
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	return backups[idx], nil
+}
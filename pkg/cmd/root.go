@@ -44,6 +44,12 @@ func AddSubCmd(cmd *cobra.Command, config *globalConfig) *cobra.Command {
 	cmd.AddCommand(NewCmdDescribe(config.configFlags, config.streams))
 	cmd.AddCommand(NewCmdCreate(config.configFlags, config.streams))
 	cmd.AddCommand(NewCmdDelete(config.configFlags, config.streams))
+	cmd.AddCommand(NewCmdCronJob(config.configFlags, config.streams))
+	cmd.AddCommand(NewCmdTree(config.configFlags, config.streams))
+	cmd.AddCommand(NewCmdFinalize(config.configFlags, config.streams))
+	cmd.AddCommand(NewCmdRestore(config.configFlags, config.streams))
+	cmd.AddCommand(NewCmdHistory(config.configFlags, config.streams))
+	cmd.AddCommand(NewCmdReplay(config.streams))
 	cmd.AddCommand(NewCmdVersion())
 
 	return cmd
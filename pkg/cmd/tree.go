@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/fuzzyfinder"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	exampleTree = `
+	# Selecting an object with the fuzzy finder and show its dependents as a tree
+	kubectl fuzzy tree TYPE [flags]
+
+	# Show the dependency tree of the objects in the preview window
+	kubectl fuzzy tree TYPE --preview --preview-format=tree [flags]
+
+	# Select the namespace, the resource type and then the object level by level
+	kubectl fuzzy tree --drill-down [flags]
+`
+)
+
+// NewCmdTree provides a cobra command wrapping TreeOptions.
+func NewCmdTree(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *cobra.Command {
+	o := NewTreeOptions(config, streams)
+
+	cmd := &cobra.Command{
+		Use:           "tree",
+		Short:         "Selecting an object with the fuzzy finder and show its dependents as a tree",
+		Example:       exampleTree,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+
+			if err := o.Validate(); err != nil {
+				return err
+			}
+
+			return o.Run(c.Context(), args)
+		},
+	}
+
+	o.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// TreeOptions provides information required to show the dependency tree of an object.
+type TreeOptions struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+
+	allNamespaces bool
+	namespace     string
+	selector      string
+
+	finder    finderFlags
+	preview   previewFlags
+	drillDown bool
+}
+
+// NewTreeOptions provides an instance of TreeOptions with default values.
+func NewTreeOptions(config *genericclioptions.ConfigFlags, streams genericclioptions.IOStreams) *TreeOptions {
+	return &TreeOptions{
+		configFlags: config,
+		preview:     previewFlags{objectFormats: true},
+		IOStreams:   streams,
+	}
+}
+
+// AddFlags adds a flag to the flag set.
+func (o *TreeOptions) AddFlags(flags *pflag.FlagSet) {
+	// kubectl flags
+	flags.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false,
+		"If present, list the requested object(s) across all namespaces. "+
+			"Namespace in current context is ignored even if specified with --namespace.")
+	flags.StringVarP(&o.selector, "selector", "l", "",
+		"Selector (label query) to filter on, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+
+	// original flags
+	flags.BoolVar(&o.drillDown, "drill-down", false,
+		"If true, select the namespace, the resource type if TYPE is omitted, and then the object "+
+			"with the fuzzy finder level by level. Esc returns to the previous level. "+
+			"The namespace level shows the number of objects in each namespace only if TYPE is given, "+
+			"and is skipped if --namespace is given without --all-namespaces.")
+	o.finder.AddFlags(flags)
+	o.preview.AddFlags(flags)
+}
+
+// Complete sets all information required for showing the tree.
+func (o *TreeOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.preview.Complete()
+
+	if !o.allNamespaces {
+		namespace, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return fmt.Errorf("faild to get namespace from kube config: %w", err)
+		}
+
+		o.namespace = namespace
+	}
+
+	return nil
+}
+
+// Validate ensures that all required arguments and flag values are provided.
+func (o *TreeOptions) Validate() error {
+	if err := o.preview.Validate(); err != nil {
+		return err
+	}
+
+	if err := o.finder.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Run execute fizzy finder and show the dependency tree.
+func (o *TreeOptions) Run(ctx context.Context, args []string) error {
+	tree, err := newTreePrinter(ctx, o.configFlags)
+	if err != nil {
+		return err
+	}
+
+	o.preview.wrapPrinter = func(printer printers.ResourcePrinter) printers.ResourcePrinter {
+		if o.preview.previewFormat == previewFormatTree {
+			// share the owner graphs with the output
+			return tree
+		}
+
+		return printer
+	}
+
+	previewOpts, err := o.preview.finderOptions(ctx, o.configFlags)
+	if err != nil {
+		return err
+	}
+
+	hist, err := newSelectionHistory(o.configFlags, o.finder.noHistory, o.ErrOut)
+	if err != nil {
+		return err
+	}
+
+	finderOpts := append(previewOpts,
+		fuzzyfinder.WithAllNamespaces(o.allNamespaces),
+		fuzzyfinder.WithScore(hist.score))
+	finderOpts = append(finderOpts, o.finder.options(o.ErrOut)...)
+
+	info, err := o.selectInfo(args, finderOpts)
+	if err != nil {
+		return err
+	}
+
+	hist.record(info)
+
+	return tree.PrintObj(info.Object, o.Out)
+}
+
+// selectInfo selects the object to show the tree of with the fuzzy finder.
+func (o *TreeOptions) selectInfo(args []string, finderOpts []fuzzyfinder.Option) (*resource.Info, error) {
+	if o.drillDown {
+		d := &drillDown{
+			configFlags: o.configFlags,
+			namespace:   drillDownNamespace(o.configFlags, o.allNamespaces),
+			finderOpts:  o.finder.levelOptions(o.ErrOut),
+			verbs:       []string{"list"},
+			builder: func() *resource.Builder {
+				return resource.NewBuilder(o.configFlags).
+					Unstructured().
+					ContinueOnError().
+					LabelSelectorParam(o.selector).
+					Flatten()
+			},
+			selectObject: func(infos []*resource.Info, header string) (*resource.Info, error) {
+				return fuzzyfinder.Infos(infos, append(finderOpts,
+					fuzzyfinder.WithAllNamespaces(false),
+					fuzzyfinder.WithHeader(header))...)
+			},
+		}
+
+		return d.Run(args)
+	}
+
+	r := resource.NewBuilder(o.configFlags).
+		Unstructured().
+		ContinueOnError().
+		NamespaceParam(o.namespace).DefaultNamespace().AllNamespaces(o.allNamespaces).
+		LabelSelectorParam(o.selector).
+		ResourceTypeOrNameArgs(true, args...).
+		Flatten().
+		Do()
+
+	if err := r.Err(); err != nil {
+		return nil, fmt.Errorf("failed to request: %w", err)
+	}
+
+	infos, err := r.Infos()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get infos: %w", err)
+	}
+
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("resource not found")
+	}
+
+	info, err := fuzzyfinder.Infos(infos, finderOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fuzzyfinder execute: %w", err)
+	}
+
+	return info, nil
+}
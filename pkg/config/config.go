@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// EnvVar is the environment variable specifying the path of the configuration file.
+	EnvVar = "KUBE_FUZZY_CONFIG"
+)
+
+// Config represents the configuration file of kubectl-fuzzy.
+type Config struct {
+	Delete  Delete  `json:"delete,omitempty"`
+	Preview Preview `json:"preview,omitempty"`
+	Finder  Finder  `json:"finder,omitempty"`
+	History History `json:"history,omitempty"`
+}
+
+// Delete represents the configuration of the delete command.
+type Delete struct {
+	Protection Protection `json:"protection,omitempty"`
+}
+
+// Preview represents the configuration of the preview window.
+type Preview struct {
+	Simplify Simplify `json:"simplify,omitempty"`
+}
+
+// DefaultPath returns the default path of the configuration file.
+func DefaultPath() string {
+	return filepath.Join(homedir.HomeDir(), ".kube", "fuzzy", "config.yaml")
+}
+
+// Load loads the configuration file from the path specified by the KUBE_FUZZY_CONFIG environment variable,
+// or from the default path. The default values are used if the file does not exist.
+func Load() (*Config, error) {
+	path := os.Getenv(EnvVar)
+	if path == "" {
+		path = DefaultPath()
+	}
+
+	c := &Config{}
+
+	b, err := os.ReadFile(path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	default:
+		if err := yaml.UnmarshalStrict(b, c); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	c.setDefaults()
+
+	return c, nil
+}
+
+func (c *Config) setDefaults() {
+	c.Delete.Protection.setDefaults()
+	c.History.setDefaults()
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Finder represents the configuration of the fuzzy finder.
+type Finder struct {
+	// Lines is the list of the templates of the candidate lines. The first template matching the kind is used.
+	Lines []Line `json:"lines,omitempty"`
+}
+
+// Line represents the template of the candidate lines of the objects of the kinds.
+type Line struct {
+	// Kinds is the list of kinds in the form of "Kind" or "Kind.group" (e.g. Ingress.networking.k8s.io).
+	// "*" or an empty list applies the template to all kinds.
+	Kinds []string `json:"kinds,omitempty"`
+	// Template is a Go template or a JSONPath template prefixed with "jsonpath=" over the object
+	// (e.g. "{{.metadata.name}} {{.status.phase}}").
+	Template string `json:"template"`
+}
+
+// LineTemplate returns the template of the candidate lines of the kind, or an empty string if none matches.
+func (f *Finder) LineTemplate(gk schema.GroupKind) string {
+	for _, line := range f.Lines {
+		if len(line.Kinds) == 0 {
+			return line.Template
+		}
+
+		for _, kind := range line.Kinds {
+			if kind == "*" || matchKind(kind, gk) {
+				return line.Template
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/history"
+)
+
+// History represents the configuration of the history of the objects selected with the fuzzy finder.
+type History struct {
+	// Disabled disables recording the selections and ranking the candidates by frecency.
+	Disabled bool `json:"disabled,omitempty"`
+	// DisabledContexts is the list of kubeconfig context name patterns (e.g. prod-*) whose selections are
+	// not recorded. The patterns are matched with the path.Match syntax.
+	DisabledContexts []string `json:"disabledContexts,omitempty"`
+	// Path is the path of the history file.
+	// Defaults to ~/.kube/fuzzy/history.jsonl.
+	Path string `json:"path,omitempty"`
+}
+
+func (h *History) setDefaults() {
+	if h.Path == "" {
+		h.Path = history.DefaultPath()
+	}
+}
+
+// Enabled reports whether the history is enabled for the kubeconfig context.
+func (h *History) Enabled(context string) (bool, error) {
+	if h.Disabled {
+		return false, nil
+	}
+
+	for _, pattern := range h.DisabledContexts {
+		matched, err := path.Match(pattern, context)
+		if err != nil {
+			return false, fmt.Errorf("invalid disabled context pattern %q: %w", pattern, err)
+		}
+
+		if matched {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Protection represents the rules of objects that require typing their name to confirm the deletion.
+type Protection struct {
+	// Namespaces is the list of namespaces whose objects are protected.
+	// Defaults to kube-system.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// LabelSelectors is the list of label selectors matching protected objects.
+	// Defaults to fuzzy.io/protected=true.
+	LabelSelectors []string `json:"labelSelectors,omitempty"`
+	// Kinds is the list of protected kinds in the form of "Kind" or "Kind.group" (e.g. StatefulSet.apps).
+	// Defaults to Namespace, PersistentVolume and CustomResourceDefinition.apiextensions.k8s.io.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+func (p *Protection) setDefaults() {
+	if p.Namespaces == nil {
+		p.Namespaces = []string{"kube-system"}
+	}
+
+	if p.LabelSelectors == nil {
+		p.LabelSelectors = []string{"fuzzy.io/protected=true"}
+	}
+
+	if p.Kinds == nil {
+		p.Kinds = []string{"Namespace", "PersistentVolume", "CustomResourceDefinition.apiextensions.k8s.io"}
+	}
+}
+
+// Protected reports whether the object is protected by the rules and returns the reason.
+func (p *Protection) Protected(namespace string, gk schema.GroupKind, objLabels map[string]string) (string, bool, error) {
+	for _, ns := range p.Namespaces {
+		if namespace != "" && namespace == ns {
+			return fmt.Sprintf("namespace %q is protected", ns), true, nil
+		}
+	}
+
+	for _, s := range p.LabelSelectors {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid protected label selector %q: %w", s, err)
+		}
+
+		if selector.Matches(labels.Set(objLabels)) {
+			return fmt.Sprintf("labels match protected selector %q", s), true, nil
+		}
+	}
+
+	for _, kind := range p.Kinds {
+		if matchKind(kind, gk) {
+			return fmt.Sprintf("kind %q is protected", kind), true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// matchKind reports whether the kind in the form of "Kind" or "Kind.group" matches the GroupKind.
+func matchKind(kind string, gk schema.GroupKind) bool {
+	if strings.EqualFold(kind, gk.Kind) {
+		return true
+	}
+
+	return strings.EqualFold(kind, gk.String())
+}
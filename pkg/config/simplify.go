@@ -0,0 +1,35 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/printers"
+)
+
+// Simplify represents the rules simplifying the objects displayed in the preview window.
+type Simplify struct {
+	// RuleSets is the list of the names of the built-in rule sets to apply (e.g. neat).
+	RuleSets []string `json:"ruleSets,omitempty"`
+	// Rules is the list of rules applied after the built-in rule sets.
+	Rules printers.Rules `json:"rules,omitempty"`
+}
+
+// ToRules returns the rules of the built-in rule sets followed by the configured rules.
+func (s *Simplify) ToRules() (printers.Rules, error) {
+	var rules printers.Rules
+
+	for _, name := range s.RuleSets {
+		builtin, err := printers.BuiltinRules(name)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, builtin...)
+	}
+
+	if err := s.Rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid simplify rules: %w", err)
+	}
+
+	return append(rules, s.Rules...), nil
+}
@@ -2,16 +2,32 @@ package fuzzyfinder
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/d-kuro/kubectl-fuzzy/pkg/printers"
 	"github.com/ktr0731/go-fuzzyfinder"
+	"github.com/ktr0731/go-fuzzyfinder/matching"
+	"github.com/ktr0731/go-fuzzyfinder/scoring"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	kprinters "k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
 )
 
+// ErrNoMatch is returned if no candidates match the query with the exit-zero option
+// or in the non-interactive mode.
+var ErrNoMatch = errors.New("no candidates match the query")
+
+// ErrAbort is returned if the fuzzy finder is closed without a selection (e.g. with Esc).
+var ErrAbort = fuzzyfinder.ErrAbort
+
 // Option represents available fuzzy-finding options.
 type Option func(*opt)
 
@@ -19,6 +35,18 @@ type opt struct {
 	allNamespaces bool
 	printer       kprinters.ResourcePrinter
 	rawPreview    bool
+	rules         printers.Rules
+	theme         *printers.Theme
+	line          func(info *resource.Info) string
+	query         string
+	selectOne     bool
+	exitZero      bool
+	pick          string
+	errOut        io.Writer
+	score         func(info *resource.Info) float64
+	header        string
+	preview       func(i int) string
+	object        *types.NamespacedName
 }
 
 // WithAllNamespaces specifies whether to display the namespace during fuzzy-finding.
@@ -45,56 +73,238 @@ func WithRawPreview(rawPreview bool) Option {
 	}
 }
 
+// WithSimplifyRules specifies the rules applied to the simplified object displayed in the fuzzy-finding preview.
+func WithSimplifyRules(rules printers.Rules) Option {
+	return func(o *opt) {
+		o.rules = rules
+	}
+}
+
+// WithHighlight specifies the theme highlighting the fuzzy-finding preview.
+// Default is nil, the preview is not highlighted.
+func WithHighlight(theme *printers.Theme) Option {
+	return func(o *opt) {
+		o.theme = theme
+	}
+}
+
+// WithLine specifies the function returning the line displayed for each info during fuzzy-finding.
+// The default line is displayed if the function returns an empty string.
+// Default is the name of the info.
+func WithLine(line func(info *resource.Info) string) Option {
+	return func(o *opt) {
+		o.line = line
+	}
+}
+
+// WithQuery specifies the initial query of the fuzzy finder.
+func WithQuery(query string) Option {
+	return func(o *opt) {
+		o.query = query
+	}
+}
+
+// WithSelectOne specifies whether to select the candidate without starting the fuzzy finder
+// if exactly one candidate matches the query.
+// Default is false.
+func WithSelectOne(selectOne bool) Option {
+	return func(o *opt) {
+		o.selectOne = selectOne
+	}
+}
+
+// WithExitZero specifies whether to return ErrNoMatch without starting the fuzzy finder
+// if no candidates match the query.
+// Default is false.
+func WithExitZero(exitZero bool) Option {
+	return func(o *opt) {
+		o.exitZero = exitZero
+	}
+}
+
+// WithScore specifies the function returning the score of each info (e.g. the frecency of the selections).
+// The infos are sorted by the score in descending order so that the infos with higher scores
+// are displayed first before typing. Infos with the same score keep their order.
+// Default is nil, the infos are not sorted.
+func WithScore(score func(info *resource.Info) float64) Option {
+	return func(o *opt) {
+		o.score = score
+	}
+}
+
+// WithHeader specifies the header displayed above the candidates during fuzzy-finding.
+func WithHeader(header string) Option {
+	return func(o *opt) {
+		o.header = header
+	}
+}
+
+// WithPreviewFunc specifies the function returning the text of the preview window for the candidate at the index.
+// It is used by Lines.
+func WithPreviewFunc(preview func(i int) string) Option {
+	return func(o *opt) {
+		o.preview = preview
+	}
+}
+
+// WithObject restricts the candidates of Infos and InfosMulti to the objects with the namespace and the name
+// (e.g. to re-select the object of the history). The namespace is empty for cluster-scoped objects.
+// A single object is selected without starting the fuzzy finder.
+// Default is no restriction.
+func WithObject(namespace, name string) Option {
+	return func(o *opt) {
+		o.object = &types.NamespacedName{Namespace: namespace, Name: name}
+	}
+}
+
 // Infos will start a fuzzy finder based on the received infos and returns the selected info.
 func Infos(infos []*resource.Info, opts ...Option) (*resource.Info, error) {
-	var opt opt
+	opt := newOpt(opts)
+
+	infos, err := objectInfos(infos, opt)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, o := range opts {
-		o(&opt)
+	if len(infos) == 1 && opt.object != nil {
+		return infos[0], nil
 	}
 
+	infos = sortInfos(infos, opt.score)
+	itemFunc, finderOpts := infosFinder(infos, opt)
+
+	idx, err := find(infos, len(infos), itemFunc, infoCreatedAt(infos), opt, finderOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return infos[idx], nil
+}
+
+// InfosMulti will start a fuzzy finder based on the received infos and returns the selected infos.
+// Multiple infos can be selected with the Tab key.
+func InfosMulti(infos []*resource.Info, opts ...Option) ([]*resource.Info, error) {
+	opt := newOpt(opts)
+
+	infos, err := objectInfos(infos, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(infos) == 1 && opt.object != nil {
+		return infos, nil
+	}
+
+	infos = sortInfos(infos, opt.score)
+	itemFunc, finderOpts := infosFinder(infos, opt)
+
+	idxs, err := findMulti(infos, len(infos), itemFunc, infoCreatedAt(infos), opt, finderOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]*resource.Info, 0, len(idxs))
+	for _, idx := range idxs {
+		selected = append(selected, infos[idx])
+	}
+
+	return selected, nil
+}
+
+// objectInfos returns the infos of the object specified by WithObject.
+// ErrNoMatch is returned if the object is not found.
+func objectInfos(infos []*resource.Info, opt opt) ([]*resource.Info, error) {
+	if opt.object == nil {
+		return infos, nil
+	}
+
+	var matched []*resource.Info
+
+	for _, info := range infos {
+		if info.Namespace == opt.object.Namespace && info.Name == opt.object.Name {
+			matched = append(matched, info)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w: %s not found", ErrNoMatch, opt.object)
+	}
+
+	return matched, nil
+}
+
+func infosFinder(infos []*resource.Info, opt opt) (func(i int) string, []fuzzyfinder.Option) {
 	var finderOpts []fuzzyfinder.Option
 
 	if opt.printer != nil {
 		if !opt.rawPreview {
-			opt.printer = &printers.Simplify{Delegate: opt.printer}
+			opt.printer = &printers.Simplify{Delegate: opt.printer, Rules: opt.rules}
+		}
+
+		if opt.theme != nil {
+			opt.printer = &printers.Highlight{Delegate: opt.printer, Theme: *opt.theme}
 		}
 
 		finderOpts = append(finderOpts, infoPreviewWindow(infos, opt.printer))
 	}
 
-	printWithKind := multipleGVKsRequested(infos)
+	if opt.header != "" {
+		finderOpts = append(finderOpts, fuzzyfinder.WithHeader(opt.header))
+	}
 
-	idx, err := fuzzyfinder.Find(infos,
-		func(i int) string {
-			var b strings.Builder
+	printWithKind := multipleGVKsRequested(infos)
 
-			if printWithKind {
-				fmt.Fprintf(&b, "%s/", strings.ToLower(infos[i].Mapping.GroupVersionKind.GroupKind().String()))
+	itemFunc := func(i int) string {
+		if opt.line != nil {
+			if line := opt.line(infos[i]); line != "" {
+				return line
 			}
+		}
 
-			fmt.Fprintf(&b, "%s", infos[i].Name)
+		var b strings.Builder
 
-			if opt.allNamespaces && len(infos[i].Namespace) >= 1 {
-				fmt.Fprintf(&b, " (%s)", infos[i].Namespace)
-			}
+		if printWithKind {
+			fmt.Fprintf(&b, "%s/", strings.ToLower(infos[i].Mapping.GroupVersionKind.GroupKind().String()))
+		}
 
-			return b.String()
-		},
-		finderOpts...,
-	)
-	if err != nil {
-		return nil, err
+		fmt.Fprintf(&b, "%s", infos[i].Name)
+
+		if opt.allNamespaces && len(infos[i].Namespace) >= 1 {
+			fmt.Fprintf(&b, " (%s)", infos[i].Namespace)
+		}
+
+		return b.String()
 	}
 
-	return infos[idx], nil
+	return itemFunc, finderOpts
+}
+
+// sortInfos returns a copy of the infos sorted by the score in descending order.
+func sortInfos(infos []*resource.Info, score func(info *resource.Info) float64) []*resource.Info {
+	if score == nil {
+		return infos
+	}
+
+	scores := make(map[*resource.Info]float64, len(infos))
+	for _, info := range infos {
+		scores[info] = score(info)
+	}
+
+	sorted := append([]*resource.Info{}, infos...)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return scores[sorted[i]] > scores[sorted[j]]
+	})
+
+	return sorted
 }
 
-func Containers(containers []corev1.Container) (corev1.Container, error) {
-	idx, err := fuzzyfinder.Find(containers,
+// Containers will start a fuzzy finder based on the received containers and returns the selected container.
+func Containers(containers []corev1.Container, opts ...Option) (corev1.Container, error) {
+	idx, err := find(containers, len(containers),
 		func(i int) string {
 			return containers[i].Name
-		})
+		}, nil, newOpt(opts))
 	if err != nil {
 		return corev1.Container{}, err
 	}
@@ -102,6 +312,77 @@ func Containers(containers []corev1.Container) (corev1.Container, error) {
 	return containers[idx], nil
 }
 
+// Namespaces will start a fuzzy finder based on the received namespaces and returns the selected namespace.
+// The number of objects in each namespace is displayed if counts is not nil.
+// The cluster-scoped objects are counted in the empty namespace.
+func Namespaces(namespaces []string, counts map[string]int, opts ...Option) (string, error) {
+	opt := newOpt(opts)
+
+	idx, err := find(namespaces, len(namespaces),
+		func(i int) string {
+			name := namespaces[i]
+			if name == "" {
+				name = "(cluster-scoped)"
+			}
+
+			if counts == nil {
+				return name
+			}
+
+			return fmt.Sprintf("%s (%d)", name, counts[namespaces[i]])
+		}, nil, opt,
+		fuzzyfinder.WithHeader(opt.header))
+	if err != nil {
+		return "", err
+	}
+
+	return namespaces[idx], nil
+}
+
+// Lines will start a fuzzy finder based on the received lines and returns the index of the selected line.
+func Lines(lines []string, opts ...Option) (int, error) {
+	opt := newOpt(opts)
+
+	var finderOpts []fuzzyfinder.Option
+
+	if opt.preview != nil {
+		finderOpts = append(finderOpts, fuzzyfinder.WithPreviewWindow(func(i, w, h int) string {
+			if i < 0 {
+				return ""
+			}
+
+			return opt.preview(i)
+		}))
+	}
+
+	if opt.header != "" {
+		finderOpts = append(finderOpts, fuzzyfinder.WithHeader(opt.header))
+	}
+
+	return find(lines, len(lines), func(i int) string {
+		return lines[i]
+	}, nil, opt, finderOpts...)
+}
+
+// Finalizers will start a fuzzy finder based on the received finalizers and returns the selected finalizers.
+// Multiple finalizers can be selected with the Tab key.
+func Finalizers(finalizers []string, opts ...Option) ([]string, error) {
+	idxs, err := findMulti(finalizers, len(finalizers),
+		func(i int) string {
+			return finalizers[i]
+		}, nil, newOpt(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make([]string, 0, len(idxs))
+	for _, idx := range idxs {
+		selected = append(selected, finalizers[idx])
+	}
+
+	return selected, nil
+}
+
 func infoPreviewWindow(infos []*resource.Info, printer kprinters.ResourcePrinter) fuzzyfinder.Option {
 	return fuzzyfinder.WithPreviewWindow(func(i, w, h int) string {
 		if i >= 0 {
@@ -133,3 +414,122 @@ func multipleGVKsRequested(infos []*resource.Info) bool {
 
 	return false
 }
+
+func newOpt(opts []Option) opt {
+	o := opt{errOut: os.Stderr}
+
+	for _, f := range opts {
+		f(&o)
+	}
+
+	return o
+}
+
+// find starts the fuzzy finder unless the candidate is determined by the query
+// with the select-one or the exit-zero option, or is picked non-interactively.
+// createdAt returns the creation time of the candidate used by the newest and oldest picks, nil if unknown.
+func find(slice interface{}, n int, itemFunc func(i int) string, createdAt func(i int) time.Time, o opt,
+	finderOpts ...fuzzyfinder.Option) (int, error) {
+	if o.nonInteractive() {
+		return pick(n, itemFunc, createdAt, o)
+	}
+
+	matched, err := matchQuery(n, itemFunc, o)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(matched) == 1 && o.selectOne {
+		return matched[0], nil
+	}
+
+	return fuzzyfinder.Find(slice, itemFunc, append(finderOpts, fuzzyfinder.WithQuery(o.query))...)
+}
+
+// findMulti is the multiple selection version of find.
+// A single candidate is picked in the non-interactive mode.
+func findMulti(slice interface{}, n int, itemFunc func(i int) string, createdAt func(i int) time.Time, o opt,
+	finderOpts ...fuzzyfinder.Option) ([]int, error) {
+	if o.nonInteractive() {
+		idx, err := pick(n, itemFunc, createdAt, o)
+		if err != nil {
+			return nil, err
+		}
+
+		return []int{idx}, nil
+	}
+
+	matched, err := matchQuery(n, itemFunc, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matched) == 1 && o.selectOne {
+		return matched, nil
+	}
+
+	return fuzzyfinder.FindMulti(slice, itemFunc, append(finderOpts, fuzzyfinder.WithQuery(o.query))...)
+}
+
+// matchQuery returns the indexes of the candidates matching the query for the select-one and the exit-zero options.
+// ErrNoMatch is returned if no candidates match with the exit-zero option.
+// Nil is returned without matching if neither the select-one nor the exit-zero option is enabled.
+func matchQuery(n int, itemFunc func(i int) string, o opt) ([]int, error) {
+	if !o.selectOne && !o.exitZero {
+		return nil, nil
+	}
+
+	idxs := matchAll(n, itemFunc, o.query)
+
+	if len(idxs) == 0 && o.exitZero {
+		return nil, ErrNoMatch
+	}
+
+	return idxs, nil
+}
+
+// matchAll returns the indexes of the candidates matching the query with the scoring of the fuzzy finder,
+// best match first. Ties are ordered by the index, which the fuzzy finder orders the other way around.
+// All candidates match the empty query in their order.
+func matchAll(n int, itemFunc func(i int) string, query string) []int {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = itemFunc(i)
+	}
+
+	var idxs []int
+
+	if query == "" {
+		// the matching requires a non-empty query
+		for i := range items {
+			idxs = append(idxs, i)
+		}
+
+		return idxs
+	}
+
+	// the smart case of the matching: case-insensitive unless the query has an upper-case letter
+	caseInsensitive := strings.IndexFunc(query, unicode.IsUpper) < 0
+
+	scores := make(map[int]int)
+
+	for _, m := range matching.FindAll(query, items) {
+		item, in := items[m.Idx], query
+		if caseInsensitive {
+			item, in = strings.ToLower(item), strings.ToLower(in)
+		}
+
+		scores[m.Idx], _ = scoring.Calculate(item, in)
+		idxs = append(idxs, m.Idx)
+	}
+
+	sort.Slice(idxs, func(i, j int) bool {
+		if scores[idxs[i]] != scores[idxs[j]] {
+			return scores[idxs[i]] > scores[idxs[j]]
+		}
+
+		return idxs[i] < idxs[j]
+	})
+
+	return idxs
+}
@@ -0,0 +1,110 @@
+package fuzzyfinder
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestMatchQuery(t *testing.T) {
+	items := []string{"nginx-a", "redis", "nginx-b"}
+
+	tests := []struct {
+		name    string
+		o       opt
+		want    []int
+		wantErr error
+	}{
+		{name: "no options skip matching", o: opt{query: "nginx"}, want: nil},
+		{name: "select-1 with a single match", o: opt{query: "redis", selectOne: true}, want: []int{1}},
+		{name: "select-1 with multiple matches", o: opt{query: "nginx", selectOne: true}, want: []int{0, 2}},
+		{name: "select-1 without matches", o: opt{query: "mysql", selectOne: true}, want: nil},
+		{name: "exit-0 with matches", o: opt{query: "nginx", exitZero: true}, want: []int{0, 2}},
+		{name: "exit-0 without matches", o: opt{query: "mysql", exitZero: true}, wantErr: ErrNoMatch},
+		{name: "exit-0 with the empty query", o: opt{exitZero: true}, want: []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchQuery(len(items), func(i int) string { return items[i] }, tt.o)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("matchQuery() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInfosNonInteractive(t *testing.T) {
+	mapping := &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}}
+	infos := []*resource.Info{
+		{Namespace: "default", Name: "nginx-7d9c", Mapping: mapping},
+		{Namespace: "default", Name: "redis-0", Mapping: mapping},
+		{Namespace: "monitoring", Name: "prometheus-0", Mapping: mapping},
+	}
+
+	nodes := map[string]string{"nginx-7d9c": "node-a", "redis-0": "node-b"}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    string
+		wantErr error
+		// wantReport is the candidate reported on stderr.
+		wantReport string
+	}{
+		{name: "query", opts: []Option{WithQuery("redis")}, want: "redis-0", wantReport: "redis-0"},
+		{
+			name:       "query matches the namespace of all namespaces",
+			opts:       []Option{WithQuery("monitoring"), WithAllNamespaces(true)},
+			want:       "prometheus-0",
+			wantReport: "prometheus-0 (monitoring)",
+		},
+		{
+			name: "query matches the rendered line",
+			opts: []Option{WithQuery("node-b"), WithLine(func(info *resource.Info) string {
+				if node, ok := nodes[info.Name]; ok {
+					return info.Name + " " + node
+				}
+
+				return ""
+			})},
+			want:       "redis-0",
+			wantReport: "redis-0 node-b",
+		},
+		{name: "exit-0 without matches", opts: []Option{WithQuery("mysql"), WithExitZero(true)}, wantErr: ErrNoMatch},
+		{name: "no matches", opts: []Option{WithQuery("mysql")}, wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errOut bytes.Buffer
+
+			// the pick keeps the fuzzy finder from starting on the terminal of the test
+			got, err := Infos(infos, append([]Option{WithPick(PickBest), WithErrOut(&errOut)}, tt.opts...)...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Infos() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Name != tt.want {
+				t.Errorf("Infos() = %q, want %q", got.Name, tt.want)
+			}
+
+			if !bytes.Contains(errOut.Bytes(), []byte(tt.wantReport)) {
+				t.Errorf("errOut = %q, want the report of %q", errOut.String(), tt.wantReport)
+			}
+		})
+	}
+}
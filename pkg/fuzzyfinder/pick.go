@@ -0,0 +1,145 @@
+package fuzzyfinder
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/moby/term"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+const (
+	// PickBest picks the candidate best matching the query.
+	// Ties go to the earlier candidate, e.g. the one ranked higher by the history.
+	PickBest = "best"
+	// PickNewest picks the most recently created candidate matching the query.
+	PickNewest = "newest"
+	// PickOldest picks the least recently created candidate matching the query.
+	PickOldest = "oldest"
+	// PickFirst picks the first candidate matching the query in the order of the candidates.
+	PickFirst = "first"
+)
+
+// Picks returns the names of the ways to pick a candidate non-interactively.
+func Picks() []string {
+	return []string{PickBest, PickNewest, PickOldest, PickFirst}
+}
+
+// ErrAmbiguous is returned if the fuzzy finder cannot be started and more than one candidate matches
+// the empty query without an explicit pick.
+var ErrAmbiguous = errors.New("more than one candidate and no terminal to start the fuzzy finder, " +
+	"specify a query or how to pick the candidate")
+
+// WithPick specifies the way to pick a candidate matching the query without starting the fuzzy finder.
+// If empty, the candidate is picked with PickBest only if the terminal cannot be opened,
+// and ErrAmbiguous is returned instead if the query is empty and more than one candidate matches.
+// Default is empty.
+func WithPick(pick string) Option {
+	return func(o *opt) {
+		o.pick = pick
+	}
+}
+
+// WithErrOut specifies the writer reporting the candidate picked non-interactively.
+// Default is os.Stderr.
+func WithErrOut(errOut io.Writer) Option {
+	return func(o *opt) {
+		o.errOut = errOut
+	}
+}
+
+// Interactive reports whether the fuzzy finder is started with the options
+// rather than a candidate is picked non-interactively.
+func Interactive(opts ...Option) bool {
+	o := newOpt(opts)
+
+	return !o.nonInteractive()
+}
+
+// nonInteractive reports whether to pick a candidate without starting the fuzzy finder.
+func (o *opt) nonInteractive() bool {
+	return o.pick != "" || !ttyAvailable()
+}
+
+// ttyAvailable reports whether the terminal the fuzzy finder draws on can be opened.
+// The fuzzy finder uses /dev/tty, so it works even if stdin or stdout is redirected (e.g. in a pipe).
+func ttyAvailable() bool {
+	if runtime.GOOS == "windows" {
+		return term.IsTerminal(os.Stdin.Fd())
+	}
+
+	f, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+
+	_ = f.Close()
+
+	return true
+}
+
+// pick picks a candidate matching the query deterministically and reports the choice.
+// The newest and oldest picks fall back to the first pick if the creation times are unknown.
+// Without an explicit pick, a candidate is not picked arbitrarily from the ones matching the empty query.
+func pick(n int, itemFunc func(i int) string, createdAt func(i int) time.Time, o opt) (int, error) {
+	p := o.pick
+	if p == "" {
+		p = PickBest
+	}
+
+	matched := matchAll(n, itemFunc, o.query)
+	if len(matched) == 0 {
+		return 0, ErrNoMatch
+	}
+
+	if o.pick == "" && o.query == "" && len(matched) > 1 {
+		return 0, ErrAmbiguous
+	}
+
+	var idx int
+
+	switch p {
+	case PickBest:
+		idx = matched[0]
+	case PickFirst, PickNewest, PickOldest:
+		sort.Ints(matched)
+
+		idx = matched[0]
+
+		if createdAt == nil || p == PickFirst {
+			break
+		}
+
+		for _, i := range matched[1:] {
+			if p == PickNewest && createdAt(i).After(createdAt(idx)) ||
+				p == PickOldest && createdAt(i).Before(createdAt(idx)) {
+				idx = i
+			}
+		}
+	default:
+		return 0, fmt.Errorf("unknown pick %q", p)
+	}
+
+	_, _ = fmt.Fprintf(o.errOut, "picked %q (%s of %d candidates matching the query %q)\n",
+		itemFunc(idx), p, len(matched), o.query)
+
+	return idx, nil
+}
+
+// infoCreatedAt returns the function returning the creation time of the info.
+func infoCreatedAt(infos []*resource.Info) func(i int) time.Time {
+	return func(i int) time.Time {
+		accessor, err := meta.Accessor(infos[i].Object)
+		if err != nil {
+			return time.Time{}
+		}
+
+		return accessor.GetCreationTimestamp().Time
+	}
+}
@@ -0,0 +1,178 @@
+package fuzzyfinder
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestMatchAll(t *testing.T) {
+	items := []string{"nginx-a", "redis", "nginx-b", "NGINX-c"}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{name: "empty query matches all in order", query: "", want: []int{0, 1, 2, 3}},
+		{name: "ties ordered by index", query: "nginx", want: []int{0, 2, 3}},
+		{name: "smart case", query: "NGINX", want: []int{3}},
+		{name: "no match", query: "mysql", want: nil},
+		{name: "better match first", query: "ngb", want: []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchAll(len(items), func(i int) string { return items[i] }, tt.query)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchAll(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPick(t *testing.T) {
+	items := []string{"web-1", "web-2", "db-1"}
+	created := []time.Time{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	itemFunc := func(i int) string { return items[i] }
+	createdAt := func(i int) time.Time { return created[i] }
+
+	tests := []struct {
+		name    string
+		pick    string
+		query   string
+		want    int
+		wantErr error
+	}{
+		{name: "best tie goes to the earlier candidate", pick: PickBest, query: "web", want: 0},
+		{name: "newest", pick: PickNewest, query: "web", want: 1},
+		{name: "oldest", pick: PickOldest, query: "", want: 2},
+		{name: "first", pick: PickFirst, query: "1", want: 0},
+		{name: "implicit pick with a query", pick: "", query: "db", want: 2},
+		{name: "implicit pick with a narrowing query", pick: "", query: "web", want: 0},
+		{name: "implicit pick refuses the empty query", pick: "", query: "", wantErr: ErrAmbiguous},
+		{name: "no match", pick: PickBest, query: "cache", wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pick(len(items), itemFunc, createdAt, opt{pick: tt.pick, query: tt.query, errOut: io.Discard})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("pick() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("pick() = %d (%s), want %d (%s)", got, items[got], tt.want, items[tt.want])
+			}
+		})
+	}
+}
+
+func TestPickSingleCandidateWithoutQuery(t *testing.T) {
+	got, err := pick(1, func(int) string { return "only" }, nil, opt{errOut: io.Discard})
+	if err != nil || got != 0 {
+		t.Errorf("pick() = %d, %v, want 0, nil", got, err)
+	}
+}
+
+func TestObjectInfos(t *testing.T) {
+	infos := []*resource.Info{
+		{Namespace: "default", Name: "nginx"},
+		{Namespace: "kube-system", Name: "nginx"},
+		{Name: "node-1"},
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    []*resource.Info
+		wantErr bool
+	}{
+		{name: "no restriction", want: infos},
+		{name: "namespaced", opts: []Option{WithObject("kube-system", "nginx")}, want: infos[1:2]},
+		{name: "cluster-scoped", opts: []Option{WithObject("", "node-1")}, want: infos[2:]},
+		{name: "not found", opts: []Option{WithObject("default", "redis")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := objectInfos(infos, newOpt(tt.opts))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("objectInfos() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, ErrNoMatch) {
+				t.Errorf("objectInfos() error = %v, want ErrNoMatch", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("objectInfos() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNamespacesNonInteractive(t *testing.T) {
+	namespaces := []string{"", "default", "kube-system"}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    string
+		wantErr error
+	}{
+		{name: "first", opts: []Option{WithPick(PickFirst)}, want: ""},
+		{name: "query", opts: []Option{WithPick(PickBest), WithQuery("kube")}, want: "kube-system"},
+		{name: "no match", opts: []Option{WithPick(PickBest), WithQuery("monitoring")}, wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Namespaces(namespaces, nil, append(tt.opts, WithErrOut(io.Discard))...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Namespaces() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Namespaces() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinalizersNonInteractive(t *testing.T) {
+	finalizers := []string{"kubernetes.io/pv-protection", "example.com/cleanup"}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    []string
+		wantErr error
+	}{
+		{name: "query", opts: []Option{WithQuery("cleanup")}, want: []string{"example.com/cleanup"}},
+		{name: "first", opts: []Option{WithPick(PickFirst)}, want: []string{"kubernetes.io/pv-protection"}},
+		{name: "no match", opts: []Option{WithQuery("foo")}, wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Finalizers(finalizers, append([]Option{WithPick(PickBest), WithErrOut(io.Discard)}, tt.opts...)...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Finalizers() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Finalizers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/homedir"
+)
+
+// MaxEntries is the maximum number of entries kept in the history file. The oldest entries are dropped.
+const MaxEntries = 1000
+
+const (
+	// lockTimeout is the time to wait for the lock of the history file held by another command.
+	lockTimeout = 5 * time.Second
+	// lockStale is the age of a lock file regarded as left by a crashed command.
+	lockStale = 30 * time.Second
+	// lockRetryInterval is the interval of the attempts to acquire the lock.
+	lockRetryInterval = 10 * time.Millisecond
+)
+
+// Entry represents an object selected with the fuzzy finder.
+type Entry struct {
+	// Time is the time when the object was selected.
+	Time time.Time `json:"time"`
+	// Context is the name of the kubeconfig context.
+	Context string `json:"context"`
+	// Namespace is the namespace of the object, empty if the object is cluster-scoped.
+	Namespace string `json:"namespace,omitempty"`
+	// Group, Version and Kind are the GroupVersionKind of the object.
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	// Args are the command line arguments of kubectl-fuzzy the object was selected with.
+	Args []string `json:"args"`
+}
+
+// Key identifies the selected object.
+type Key struct {
+	Context   string
+	Namespace string
+	GroupKind schema.GroupKind
+	Name      string
+}
+
+// Key returns the key of the selected object.
+func (e *Entry) Key() Key {
+	return Key{
+		Context:   e.Context,
+		Namespace: e.Namespace,
+		GroupKind: schema.GroupKind{Group: e.Group, Kind: e.Kind},
+		Name:      e.Name,
+	}
+}
+
+// DefaultPath returns the default path of the history file.
+func DefaultPath() string {
+	return filepath.Join(homedir.HomeDir(), ".kube", "fuzzy", "history.jsonl")
+}
+
+// Load loads the entries from the history file, oldest first.
+// No entries are returned if the file does not exist. Malformed lines are skipped.
+func Load(path string) ([]Entry, error) {
+	b, err := os.ReadFile(path)
+
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var entries []Entry
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, len(b)+1)
+
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// Append appends the entry to the history file and drops the oldest entries exceeding MaxEntries.
+// Concurrent commands are serialized by a lock file next to the history file so that no entries are lost.
+func Append(path string, entry Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil { //nolint:gomnd
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	unlock, err := lock(path)
+	if err != nil {
+		return err
+	}
+
+	defer unlock()
+
+	entries, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, entry)
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+
+	var buf bytes.Buffer
+
+	for _, e := range entries {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode history entry: %w", err)
+		}
+
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+
+	// write to a temporary file and rename it so that the readers never see a partially written file
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create history file: %w", err)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	return nil
+}
+
+// lock creates the lock file of the history file and returns the function removing it.
+// A lock file older than lockStale is left by a crashed command and is taken over.
+func lock(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600) //nolint:gomnd
+		if err == nil {
+			_ = f.Close()
+
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock history file: %w", err)
+		}
+
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > lockStale {
+			_ = os.Remove(lockPath)
+
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to lock history file: %s is held by another command", lockPath)
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// Frecency returns the frecency score of each selected object.
+// Each selection adds a weight decaying with its age, so that recently and frequently selected objects
+// have higher scores.
+func Frecency(entries []Entry, now time.Time) map[Key]float64 {
+	scores := make(map[Key]float64)
+
+	for i := range entries {
+		scores[entries[i].Key()] += weight(now.Sub(entries[i].Time))
+	}
+
+	return scores
+}
+
+func weight(age time.Duration) float64 {
+	switch {
+	case age < time.Hour:
+		return 4 //nolint:gomnd
+	case age < 24*time.Hour:
+		return 2 //nolint:gomnd
+	case age < 7*24*time.Hour:
+		return 1
+	default:
+		return 0.25 //nolint:gomnd
+	}
+}
+
+// CommandLine returns the shell command line running kubectl-fuzzy with the arguments.
+func CommandLine(args []string) string {
+	quoted := []string{"kubectl", "fuzzy"}
+
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+
+		quoted = append(quoted, arg)
+	}
+
+	return strings.Join(quoted, " ")
+}
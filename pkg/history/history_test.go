@@ -0,0 +1,163 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAppendConcurrently(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	const n = 20
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if err := Append(path, Entry{Context: "kind", Kind: "Pod", Name: "nginx"}); err != nil {
+				t.Errorf("Append() error = %v", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != n {
+		t.Errorf("len(Load()) = %d, want %d", len(entries), n)
+	}
+
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file is left: %v", err)
+	}
+}
+
+func TestAppendTakesOverStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	if err := os.WriteFile(path+".lock", nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := time.Now().Add(-2 * lockStale)
+	if err := os.Chtimes(path+".lock", stale, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Append(path, Entry{Name: "nginx"}); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+}
+
+func TestAppendDropsOldestEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	var buf bytes.Buffer
+
+	for i := 0; i < MaxEntries; i++ {
+		b, _ := json.Marshal(Entry{Version: "v1", Name: fmt.Sprintf("pod-%d", i)})
+		buf.Write(append(b, '\n'))
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Append(path, Entry{Version: "v1", Name: "latest"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != MaxEntries || entries[0].Name != "pod-1" || entries[len(entries)-1].Name != "latest" {
+		t.Errorf("Load() = %d entries from %q to %q, want %d from %q to %q", len(entries),
+			entries[0].Name, entries[len(entries)-1].Name, MaxEntries, "pod-1", "latest")
+	}
+}
+
+func TestFrecency(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	pod := func(name string, age time.Duration) Entry {
+		return Entry{Time: now.Add(-age), Context: "kind", Namespace: "default", Version: "v1", Kind: "Pod", Name: name}
+	}
+
+	tests := []struct {
+		name    string
+		entries []Entry
+		want    map[string]float64
+	}{
+		{name: "no entries", want: map[string]float64{}},
+		{
+			name:    "decaying weights",
+			entries: []Entry{pod("a", time.Minute), pod("b", 2*time.Hour), pod("c", 48*time.Hour), pod("d", 30*24*time.Hour)},
+			want:    map[string]float64{"a": 4, "b": 2, "c": 1, "d": 0.25},
+		},
+		{
+			name:    "frequent selections add up",
+			entries: []Entry{pod("a", time.Minute), pod("a", 2*time.Hour), pod("a", 30*24*time.Hour)},
+			want:    map[string]float64{"a": 6.25},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Frecency(tt.entries, now)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Frecency() = %v, want %v", got, tt.want)
+			}
+
+			for name, want := range tt.want {
+				key := Key{Context: "kind", Namespace: "default", GroupKind: schema.GroupKind{Kind: "Pod"}, Name: name}
+				if got[key] != want {
+					t.Errorf("Frecency()[%s] = %v, want %v", name, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandLine(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "no args", want: "kubectl fuzzy"},
+		{name: "plain args", args: []string{"logs", "-n", "default"}, want: "kubectl fuzzy logs -n default"},
+		{name: "empty arg", args: []string{"exec", "--", ""}, want: "kubectl fuzzy exec -- ''"},
+		{
+			name: "spaces and quotes",
+			args: []string{"exec", "--", "sh", "-c", "echo 'hi' $HOME"},
+			want: `kubectl fuzzy exec -- sh -c 'echo '\''hi'\'' $HOME'`,
+		},
+		{name: "glob", args: []string{"describe", "--query", "web*"}, want: "kubectl fuzzy describe --query 'web*'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommandLine(tt.args); got != tt.want {
+				t.Errorf("CommandLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,3 +13,27 @@ func NewClient(configFlags *genericclioptions.ConfigFlags) (*kubernetes.Clientse
 
 	return kubernetes.NewForConfig(config)
 }
+
+// CurrentContext returns the names of the context and the cluster used by the configFlags.
+func CurrentContext(configFlags *genericclioptions.ConfigFlags) (string, string, error) {
+	rawConfig, err := configFlags.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return "", "", err
+	}
+
+	contextName := rawConfig.CurrentContext
+	if configFlags.Context != nil && *configFlags.Context != "" {
+		contextName = *configFlags.Context
+	}
+
+	var clusterName string
+	if kubeContext, ok := rawConfig.Contexts[contextName]; ok {
+		clusterName = kubeContext.Cluster
+	}
+
+	if configFlags.ClusterName != nil && *configFlags.ClusterName != "" {
+		clusterName = *configFlags.ClusterName
+	}
+
+	return contextName, clusterName, nil
+}
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+)
+
+// OwnerGraph indexes objects by the UIDs of their owners.
+type OwnerGraph struct {
+	children map[types.UID][]*unstructured.Unstructured
+}
+
+// NewOwnerGraph lists the objects of all listable namespaced resource types in the namespace
+// and indexes them by their owner references.
+// If the namespace is empty, objects in all namespaces are listed.
+// Resource types that cannot be listed (e.g. forbidden) are skipped.
+func NewOwnerGraph(ctx context.Context, discoveryClient discovery.DiscoveryInterface,
+	dynamicClient dynamic.Interface, namespace string) (*OwnerGraph, error) {
+	resourceLists, err := discoveryClient.ServerPreferredNamespacedResources()
+	if err != nil && len(resourceLists) == 0 {
+		return nil, err
+	}
+
+	if err != nil {
+		// partial discovery failure (e.g. an unavailable aggregated API), use the discovered resources
+		klog.V(1).Info(err)
+	}
+
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list"}}, resourceLists)
+
+	g := &OwnerGraph{children: make(map[types.UID][]*unstructured.Unstructured)}
+
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			klog.V(1).Info(err)
+
+			continue
+		}
+
+		for _, r := range resourceList.APIResources {
+			gvr := gv.WithResource(r.Name)
+
+			list, err := dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				klog.V(1).Infof("failed to list %s: %s", gvr, err)
+
+				continue
+			}
+
+			for i := range list.Items {
+				g.add(&list.Items[i])
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// OwnerGraphOf indexes the objects by their owner references.
+func OwnerGraphOf(objs ...*unstructured.Unstructured) *OwnerGraph {
+	g := &OwnerGraph{children: make(map[types.UID][]*unstructured.Unstructured)}
+
+	for _, obj := range objs {
+		g.add(obj)
+	}
+
+	return g
+}
+
+func (g *OwnerGraph) add(obj *unstructured.Unstructured) {
+	for _, ref := range obj.GetOwnerReferences() {
+		g.children[ref.UID] = append(g.children[ref.UID], obj)
+	}
+}
+
+// Children returns the objects directly owned by the object with the UID.
+func (g *OwnerGraph) Children(uid types.UID) []*unstructured.Unstructured {
+	return g.children[uid]
+}
+
+// Dependents returns the objects owned by the object with the UID directly or indirectly.
+func (g *OwnerGraph) Dependents(uid types.UID) []*unstructured.Unstructured {
+	var dependents []*unstructured.Unstructured
+
+	visited := map[types.UID]bool{uid: true}
+	queue := []types.UID{uid}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, child := range g.children[current] {
+			if visited[child.GetUID()] {
+				continue
+			}
+
+			visited[child.GetUID()] = true
+			dependents = append(dependents, child)
+			queue = append(queue, child.GetUID())
+		}
+	}
+
+	return dependents
+}
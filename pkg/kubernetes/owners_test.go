@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func object(name string, uid types.UID, owners ...types.UID) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetName(name)
+	obj.SetUID(uid)
+
+	for _, owner := range owners {
+		obj.SetOwnerReferences(append(obj.GetOwnerReferences(), metav1.OwnerReference{UID: owner}))
+	}
+
+	return obj
+}
+
+func TestOwnerGraph(t *testing.T) {
+	rs := object("rs", "rs", "deploy")
+	pod1 := object("pod-1", "pod-1", "rs")
+	pod2 := object("pod-2", "pod-2", "rs")
+	standalone := object("standalone", "standalone")
+	// a and b own each other
+	a := object("a", "a", "b")
+	b := object("b", "b", "a")
+
+	g := OwnerGraphOf(rs, pod1, pod2, standalone, a, b)
+
+	tests := []struct {
+		name           string
+		uid            types.UID
+		wantChildren   []string
+		wantDependents []string
+	}{
+		{name: "indirect dependents", uid: "deploy", wantChildren: []string{"rs"}, wantDependents: []string{"rs", "pod-1", "pod-2"}},
+		{name: "direct dependents", uid: "rs", wantChildren: []string{"pod-1", "pod-2"}, wantDependents: []string{"pod-1", "pod-2"}},
+		{name: "no dependents", uid: "standalone"},
+		{name: "unknown object", uid: "unknown"},
+		{name: "cycle", uid: "a", wantChildren: []string{"b"}, wantDependents: []string{"b"}},
+	}
+
+	names := func(objs []*unstructured.Unstructured) []string {
+		var names []string
+		for _, obj := range objs {
+			names = append(names, obj.GetName())
+		}
+
+		return names
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := names(g.Children(tt.uid)); !reflect.DeepEqual(got, tt.wantChildren) {
+				t.Errorf("Children() = %v, want %v", got, tt.wantChildren)
+			}
+
+			if got := names(g.Dependents(tt.uid)); !reflect.DeepEqual(got, tt.wantDependents) {
+				t.Errorf("Dependents() = %v, want %v", got, tt.wantDependents)
+			}
+		})
+	}
+}
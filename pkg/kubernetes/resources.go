@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+)
+
+// APIResource represents a resource type served by the API server.
+type APIResource struct {
+	GroupVersionResource schema.GroupVersionResource
+	Kind                 string
+	ShortNames           []string
+	Namespaced           bool
+	Verbs                []string
+}
+
+// Name returns the name of the resource type in the form of "resource.group" accepted as TYPE by kubectl
+// (e.g. deployments.apps), or "resource" for the core group.
+func (r *APIResource) Name() string {
+	return r.GroupVersionResource.GroupResource().String()
+}
+
+// Supports reports whether the resource type supports all the verbs.
+func (r *APIResource) Supports(verbs ...string) bool {
+	for _, verb := range verbs {
+		var supported bool
+
+		for _, v := range r.Verbs {
+			if v == verb {
+				supported = true
+
+				break
+			}
+		}
+
+		if !supported {
+			return false
+		}
+	}
+
+	return true
+}
+
+// APIResources returns the preferred versions of the resource types served by the API server,
+// including the custom resources, sorted by name. Subresources are excluded.
+// Resource types of the API groups that cannot be discovered (e.g. an unavailable aggregated API) are skipped.
+func APIResources(discoveryClient discovery.DiscoveryInterface) ([]APIResource, error) {
+	resourceLists, err := discoveryClient.ServerPreferredResources()
+	if err != nil && len(resourceLists) == 0 {
+		return nil, err
+	}
+
+	if err != nil {
+		// partial discovery failure, use the discovered resources
+		klog.V(1).Info(err)
+	}
+
+	var resources []APIResource
+
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			klog.V(1).Info(err)
+
+			continue
+		}
+
+		for _, r := range resourceList.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+
+			resources = append(resources, APIResource{
+				GroupVersionResource: gv.WithResource(r.Name),
+				Kind:                 r.Kind,
+				ShortNames:           r.ShortNames,
+				Namespaced:           r.Namespaced,
+				Verbs:                r.Verbs,
+			})
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Name() < resources[j].Name()
+	})
+
+	return resources, nil
+}
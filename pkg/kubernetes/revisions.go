@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// Revision represents a revision of a workload.
+type Revision struct {
+	// Name is the name of the ReplicaSet or the ControllerRevision of the revision.
+	Name string
+	// Kind is the kind of the object of the revision, ReplicaSet or ControllerRevision.
+	Kind string
+	// Number is the revision number.
+	Number int64
+	// Template is the pod template of the ReplicaSet or the data of the ControllerRevision.
+	Template interface{}
+}
+
+// Revisions returns the revisions of the Deployment, StatefulSet or DaemonSet sorted by the revision number,
+// oldest first. The revisions of a Deployment are its ReplicaSets and the revisions of
+// a StatefulSet or a DaemonSet are its ControllerRevisions.
+// Nil is returned for other kinds.
+func Revisions(ctx context.Context, dynamicClient dynamic.Interface, obj *unstructured.Unstructured) ([]Revision, error) {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group != appsv1.GroupName {
+		return nil, nil
+	}
+
+	switch gvk.Kind {
+	case "Deployment":
+		return replicaSetRevisions(ctx, dynamicClient, obj)
+	case "StatefulSet", "DaemonSet":
+		return controllerRevisions(ctx, dynamicClient, obj)
+	default:
+		return nil, nil
+	}
+}
+
+func replicaSetRevisions(ctx context.Context, dynamicClient dynamic.Interface,
+	obj *unstructured.Unstructured) ([]Revision, error) {
+	list, err := dynamicClient.Resource(appsv1.SchemeGroupVersion.WithResource("replicasets")).
+		Namespace(obj.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+
+	var revisions []Revision
+
+	for i := range list.Items {
+		rs := &list.Items[i]
+		if !ownedBy(rs, obj) {
+			continue
+		}
+
+		number, err := strconv.ParseInt(rs.GetAnnotations()[deploymentRevisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		template, _, _ := unstructured.NestedMap(rs.Object, "spec", "template")
+		if labels, ok, _ := unstructured.NestedStringMap(template, "metadata", "labels"); ok {
+			delete(labels, appsv1.DefaultDeploymentUniqueLabelKey)
+			_ = unstructured.SetNestedStringMap(template, labels, "metadata", "labels")
+		}
+
+		revisions = append(revisions, Revision{Name: rs.GetName(), Kind: rs.GetKind(), Number: number, Template: template})
+	}
+
+	sortRevisions(revisions)
+
+	return revisions, nil
+}
+
+func controllerRevisions(ctx context.Context, dynamicClient dynamic.Interface,
+	obj *unstructured.Unstructured) ([]Revision, error) {
+	list, err := dynamicClient.Resource(appsv1.SchemeGroupVersion.WithResource("controllerrevisions")).
+		Namespace(obj.GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list controllerrevisions: %w", err)
+	}
+
+	var revisions []Revision
+
+	for i := range list.Items {
+		cr := &list.Items[i]
+		if !ownedBy(cr, obj) {
+			continue
+		}
+
+		number, _, _ := unstructured.NestedInt64(cr.Object, "revision")
+
+		revisions = append(revisions, Revision{
+			Name:     cr.GetName(),
+			Kind:     cr.GetKind(),
+			Number:   number,
+			Template: cr.Object["data"],
+		})
+	}
+
+	sortRevisions(revisions)
+
+	return revisions, nil
+}
+
+func ownedBy(obj, owner *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortRevisions(revisions []Revision) {
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+}
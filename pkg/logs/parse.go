@@ -0,0 +1,247 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Well-known fields of structured logs.
+const (
+	fieldTimestamp = "ts"
+	fieldLevel     = "level"
+	fieldMessage   = "msg"
+)
+
+// Severities of log levels in ascending order.
+const (
+	severityUnknown = iota
+	severityTrace
+	severityDebug
+	severityInfo
+	severityWarn
+	severityError
+	severityFatal
+)
+
+// entry is a parsed structured log line.
+type entry struct {
+	// prefix is the timestamp added by the API server when requested with timestamps.
+	prefix string
+	values map[string]string
+}
+
+// aliases returns the keys commonly used for the well-known field.
+func aliases(field string) []string {
+	switch field {
+	case fieldTimestamp:
+		return []string{"ts", "time", "timestamp", "@timestamp", "t"}
+	case fieldLevel:
+		return []string{"level", "lvl", "severity", "loglevel", "@level"}
+	case fieldMessage:
+		return []string{"msg", "message", "@message"}
+	default:
+		return []string{field}
+	}
+}
+
+// canonicalField returns the well-known field name for the key, or the key itself.
+func canonicalField(key string) string {
+	for _, field := range []string{fieldTimestamp, fieldLevel, fieldMessage} {
+		for _, alias := range aliases(field) {
+			if key == alias {
+				return field
+			}
+		}
+	}
+
+	return key
+}
+
+// resolve returns the key actually used in the entry for the field.
+func (e *entry) resolve(field string) (string, bool) {
+	if _, ok := e.values[field]; ok {
+		return field, true
+	}
+
+	for _, alias := range aliases(canonicalField(field)) {
+		if _, ok := e.values[alias]; ok {
+			return alias, true
+		}
+	}
+
+	return "", false
+}
+
+func (e *entry) lookup(field string) (string, bool) {
+	key, ok := e.resolve(field)
+	if !ok {
+		return "", false
+	}
+
+	return e.values[key], true
+}
+
+// parse parses the line with the format.
+// It returns false when the line is not a structured log line of the format.
+func parse(line, format string) (*entry, bool) {
+	prefix, body := splitTimestamp(line)
+
+	var (
+		values map[string]string
+		ok     bool
+	)
+
+	switch format {
+	case FormatJSON:
+		values, ok = parseJSON(body)
+	case FormatLogfmt:
+		values, ok = parseLogfmt(body)
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	return &entry{prefix: prefix, values: values}, true
+}
+
+// splitTimestamp splits the RFC3339 timestamp prepended to each line by "--timestamps" from the line.
+func splitTimestamp(line string) (string, string) {
+	i := strings.IndexByte(line, ' ')
+	if i < 0 {
+		return "", line
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, line[:i]); err != nil {
+		return "", line
+	}
+
+	return line[:i], line[i+1:]
+}
+
+func parseJSON(s string) (map[string]string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") {
+		return nil, false
+	}
+
+	// the numbers are kept as written, e.g. an epoch timestamp is not printed in the exponent form of float64
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+
+	var raw map[string]interface{}
+	if err := d.Decode(&raw); err != nil || d.More() {
+		return nil, false
+	}
+
+	values := make(map[string]string, len(raw))
+
+	for k, v := range raw {
+		switch v := v.(type) {
+		case string:
+			values[k] = v
+		case nil:
+			values[k] = "null"
+		case json.Number:
+			values[k] = v.String()
+		case bool:
+			values[k] = fmt.Sprint(v)
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, false
+			}
+
+			values[k] = string(b)
+		}
+	}
+
+	return values, true
+}
+
+// parseLogfmt parses a line of space-separated key=value pairs.
+// Values may be double-quoted with Go escape sequences.
+func parseLogfmt(s string) (map[string]string, bool) {
+	values := make(map[string]string)
+	s = strings.TrimSpace(s)
+
+	for len(s) > 0 {
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 || strings.ContainsAny(s[:eq], " \t\"") {
+			return nil, false
+		}
+
+		key := s[:eq]
+		s = s[eq+1:]
+
+		var value string
+
+		if strings.HasPrefix(s, `"`) {
+			end := closingQuote(s)
+			if end < 0 {
+				return nil, false
+			}
+
+			unquoted, err := strconv.Unquote(s[:end+1])
+			if err != nil {
+				return nil, false
+			}
+
+			value = unquoted
+			s = s[end+1:]
+		} else {
+			end := strings.IndexAny(s, " \t")
+			if end < 0 {
+				end = len(s)
+			}
+
+			value = s[:end]
+			s = s[end:]
+		}
+
+		values[key] = value
+		s = strings.TrimLeft(s, " \t")
+	}
+
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	return values, true
+}
+
+// closingQuote returns the index of the quote closing the quoted string at the beginning of s.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func levelSeverity(level string) int {
+	switch strings.ToLower(level) {
+	case "trace":
+		return severityTrace
+	case "debug", "dbug":
+		return severityDebug
+	case "info", "information", "notice":
+		return severityInfo
+	case "warn", "warning":
+		return severityWarn
+	case "error", "err", "eror":
+		return severityError
+	case "fatal", "panic", "critical", "crit", "dpanic", "emergency", "alert":
+		return severityFatal
+	default:
+		return severityUnknown
+	}
+}
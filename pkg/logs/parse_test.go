@@ -0,0 +1,121 @@
+package logs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLevelSeverity(t *testing.T) {
+	tests := []struct {
+		level string
+		want  int
+	}{
+		{level: "trace", want: severityTrace},
+		{level: "DEBUG", want: severityDebug},
+		{level: "dbug", want: severityDebug},
+		{level: "Info", want: severityInfo},
+		{level: "notice", want: severityInfo},
+		{level: "warning", want: severityWarn},
+		{level: "err", want: severityError},
+		{level: "eror", want: severityError},
+		{level: "panic", want: severityFatal},
+		{level: "critical", want: severityFatal},
+		{level: "verbose", want: severityUnknown},
+		{level: "", want: severityUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			if got := levelSeverity(tt.level); got != tt.want {
+				t.Errorf("levelSeverity(%q) = %d, want %d", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		format string
+		want   *entry
+	}{
+		{
+			name:   "json",
+			line:   `{"level":"info","msg":"started","port":8080,"ok":true,"err":null,"tags":["a"]}`,
+			format: FormatJSON,
+			want: &entry{values: map[string]string{
+				"level": "info", "msg": "started", "port": "8080", "ok": "true", "err": "null", "tags": `["a"]`,
+			}},
+		},
+		{
+			name:   "json with timestamp",
+			line:   `2024-01-02T03:04:05.123456789Z {"msg":"hello"}`,
+			format: FormatJSON,
+			want:   &entry{prefix: "2024-01-02T03:04:05.123456789Z", values: map[string]string{"msg": "hello"}},
+		},
+		{
+			name:   "json with epoch timestamp and large integer",
+			line:   `{"ts":1700000000.123,"size":1048576,"ratio":0.5}`,
+			format: FormatJSON,
+			want:   &entry{values: map[string]string{"ts": "1700000000.123", "size": "1048576", "ratio": "0.5"}},
+		},
+		{name: "invalid json", line: `{"msg":`, format: FormatJSON},
+		{name: "trailing data after json", line: `{"msg":"a"} {"msg":"b"}`, format: FormatJSON},
+		{name: "plain line as json", line: "starting server", format: FormatJSON},
+		{
+			name:   "logfmt",
+			line:   `level=warn msg="disk \"data\" is full" used=95%`,
+			format: FormatLogfmt,
+			want:   &entry{values: map[string]string{"level": "warn", "msg": `disk "data" is full`, "used": "95%"}},
+		},
+		{
+			name:   "logfmt with timestamp",
+			line:   "2024-01-02T03:04:05Z lvl=error",
+			format: FormatLogfmt,
+			want:   &entry{prefix: "2024-01-02T03:04:05Z", values: map[string]string{"lvl": "error"}},
+		},
+		{name: "logfmt without value", line: "level=info starting", format: FormatLogfmt},
+		{name: "logfmt with unclosed quote", line: `msg="hello`, format: FormatLogfmt},
+		{name: "plain line as logfmt", line: "GET / 200", format: FormatLogfmt},
+		{name: "empty line", line: "", format: FormatLogfmt},
+		{name: "json line as logfmt", line: `{"a":"b"}`, format: FormatLogfmt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parse(tt.line, tt.format)
+			if ok != (tt.want != nil) {
+				t.Fatalf("parse(%q) ok = %v, want %v", tt.line, ok, tt.want != nil)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryLookup(t *testing.T) {
+	e := &entry{values: map[string]string{"severity": "ERROR", "message": "failed", "time": "now"}}
+
+	tests := []struct {
+		field  string
+		want   string
+		wantOK bool
+	}{
+		{field: fieldLevel, want: "ERROR", wantOK: true},
+		{field: fieldMessage, want: "failed", wantOK: true},
+		{field: "timestamp", want: "now", wantOK: true},
+		{field: "caller"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, ok := e.lookup(tt.field)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("lookup(%q) = %q, %v, want %q, %v", tt.field, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
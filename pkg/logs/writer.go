@@ -0,0 +1,272 @@
+package logs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Supported log formats.
+const (
+	FormatRaw    = "raw"
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+)
+
+const (
+	colorReset   = "\x1b[0m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorGray    = "\x1b[90m"
+)
+
+// Option represents available log processing options.
+type Option func(*opt)
+
+type opt struct {
+	format string
+	fields []string
+	grep   *regexp.Regexp
+	level  int
+	color  bool
+}
+
+// WithFormat specifies the format used to parse each log line.
+// Lines that cannot be parsed with the format are written unchanged.
+// Default is FormatRaw.
+func WithFormat(format string) Option {
+	return func(o *opt) {
+		o.format = format
+	}
+}
+
+// WithFields specifies the fields of structured lines to be written and their order.
+// The well-known fields "ts", "level" and "msg" also match their common aliases (e.g. "time", "severity", "message").
+// Default is all fields.
+func WithFields(fields []string) Option {
+	return func(o *opt) {
+		o.fields = fields
+	}
+}
+
+// WithGrep specifies the regular expression that lines must match to be written.
+func WithGrep(re *regexp.Regexp) Option {
+	return func(o *opt) {
+		o.grep = re
+	}
+}
+
+// WithLevel specifies the minimum level of structured lines to be written.
+// Lines without a level field are always written.
+func WithLevel(level string) Option {
+	return func(o *opt) {
+		o.level = levelSeverity(level)
+	}
+}
+
+// WithColor specifies whether to colorize level fields.
+// Default is false.
+func WithColor(color bool) Option {
+	return func(o *opt) {
+		o.color = color
+	}
+}
+
+// ValidFormat reports whether the format is supported.
+func ValidFormat(format string) bool {
+	switch format {
+	case FormatRaw, FormatJSON, FormatLogfmt:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidLevel reports whether the level is known.
+func ValidLevel(level string) bool {
+	return levelSeverity(level) != severityUnknown
+}
+
+// Writer processes the written logs line by line and writes the result to the underlying writer.
+// Incomplete lines are buffered until a newline is written or Flush is called.
+type Writer struct {
+	out io.Writer
+	opt opt
+	buf []byte
+}
+
+var _ io.Writer = (*Writer)(nil)
+
+// NewWriter returns a Writer that writes processed logs to out.
+func NewWriter(out io.Writer, opts ...Option) *Writer {
+	o := opt{format: FormatRaw}
+
+	for _, fn := range opts {
+		fn(&o)
+	}
+
+	return &Writer{out: out, opt: o}
+}
+
+// Write processes every complete line in p.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := string(w.buf[:i])
+		w.buf = w.buf[i+1:]
+
+		if err := w.writeLine(line); err != nil {
+			return len(p), err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Flush processes the buffered incomplete line, if any.
+func (w *Writer) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	line := string(w.buf)
+	w.buf = nil
+
+	return w.writeLine(line)
+}
+
+func (w *Writer) writeLine(line string) error {
+	if w.opt.grep != nil && !w.opt.grep.MatchString(line) {
+		return nil
+	}
+
+	if w.opt.format == FormatRaw {
+		_, err := fmt.Fprintln(w.out, line)
+
+		return err
+	}
+
+	e, ok := parse(line, w.opt.format)
+	if !ok {
+		_, err := fmt.Fprintln(w.out, line)
+
+		return err
+	}
+
+	if w.opt.level != severityUnknown {
+		if level, ok := e.lookup(fieldLevel); ok {
+			if severity := levelSeverity(level); severity != severityUnknown && severity < w.opt.level {
+				return nil
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w.out, w.format(e))
+
+	return err
+}
+
+// format renders the entry as "<ts> <level> <msg> key=value...".
+func (w *Writer) format(e *entry) string {
+	var parts []string
+
+	if e.prefix != "" {
+		parts = append(parts, e.prefix)
+	}
+
+	fields := w.opt.fields
+	if len(fields) == 0 {
+		fields = e.defaultFields()
+	}
+
+	for _, field := range fields {
+		value, ok := e.lookup(field)
+		if !ok {
+			continue
+		}
+
+		switch canonicalField(field) {
+		case fieldLevel:
+			parts = append(parts, w.colorizeLevel(value))
+		case fieldTimestamp, fieldMessage:
+			parts = append(parts, value)
+		default:
+			parts = append(parts, fmt.Sprintf("%s=%s", field, quote(value)))
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (w *Writer) colorizeLevel(level string) string {
+	s := strings.ToUpper(level)
+
+	if !w.opt.color {
+		return s
+	}
+
+	var color string
+
+	switch levelSeverity(level) {
+	case severityTrace:
+		color = colorGray
+	case severityDebug:
+		color = colorBlue
+	case severityInfo:
+		color = colorGreen
+	case severityWarn:
+		color = colorYellow
+	case severityError:
+		color = colorRed
+	case severityFatal:
+		color = colorMagenta
+	default:
+		return s
+	}
+
+	return color + s + colorReset
+}
+
+// defaultFields returns the well-known fields followed by the remaining keys in alphabetical order.
+func (e *entry) defaultFields() []string {
+	fields := []string{fieldTimestamp, fieldLevel, fieldMessage}
+	known := make(map[string]bool)
+
+	for _, field := range fields {
+		if key, ok := e.resolve(field); ok {
+			known[key] = true
+		}
+	}
+
+	rest := make([]string, 0, len(e.values))
+
+	for key := range e.values {
+		if !known[key] {
+			rest = append(rest, key)
+		}
+	}
+
+	sort.Strings(rest)
+
+	return append(fields, rest...)
+}
+
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return s
+}
@@ -0,0 +1,231 @@
+package printers
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RuleSetDefaults is the name of the built-in rule set omitting the fields equal to the defaults
+// the API server would apply.
+const RuleSetDefaults = "defaults"
+
+// defaultRules returns the rules omitting the commonly seen fields equal to the defaults the API server would apply.
+//
+// The client-go scheme has no defaulting functions, so the defaults below are copied from
+// the SetDefaults_* functions of the API server:
+//   - https://github.com/kubernetes/kubernetes/blob/master/pkg/apis/core/v1/defaults.go
+//   - https://github.com/kubernetes/kubernetes/blob/master/pkg/apis/apps/v1/defaults.go
+//   - https://github.com/kubernetes/kubernetes/blob/master/pkg/apis/batch/v1/defaults.go
+//
+// Only the defaults not depending on other fields are listed (e.g. spec.completions of a Job is not,
+// as it is defaulted only if spec.parallelism is not set), except for imagePullPolicy handled by omitDefaults.
+// The emptied parent fields are listed after their children.
+func defaultRules() Rules {
+	specs := podSpecPaths()
+
+	kinds := make([]string, 0, len(specs))
+	for kind := range specs {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Strings(kinds)
+
+	var rules Rules
+
+	for _, kind := range kinds {
+		spec := specs[kind]
+
+		omit := []string{
+			spec + ".dnsPolicy=ClusterFirst",
+			spec + ".restartPolicy=Always",
+			spec + ".schedulerName=default-scheduler",
+			spec + ".securityContext={}",
+			spec + ".terminationGracePeriodSeconds=30",
+		}
+
+		for _, containers := range []string{"containers", "initContainers"} {
+			c := spec + "." + containers + "[*]"
+
+			omit = append(omit,
+				c+".terminationMessagePath=/dev/termination-log",
+				c+".terminationMessagePolicy=File",
+				c+".ports[*].protocol=TCP",
+				c+".env[*].valueFrom.fieldRef.apiVersion=v1",
+			)
+
+			for _, probe := range []string{"livenessProbe", "readinessProbe", "startupProbe"} {
+				omit = append(omit,
+					c+"."+probe+".timeoutSeconds=1",
+					c+"."+probe+".periodSeconds=10",
+					c+"."+probe+".successThreshold=1",
+					c+"."+probe+".failureThreshold=3",
+					c+"."+probe+".httpGet.scheme=HTTP",
+				)
+			}
+		}
+
+		for _, volume := range []string{"configMap", "secret", "projected", "downwardAPI"} {
+			omit = append(omit, spec+".volumes[*]."+volume+".defaultMode=420")
+		}
+
+		rules = append(rules, Rule{Kinds: []string{kind}, Omit: append(omit, spec+".volumes[*].hostPath.type=")})
+	}
+
+	jobSpec := []string{
+		"parallelism=1",
+		"backoffLimit=6",
+		"completionMode=NonIndexed",
+		"suspend=false",
+	}
+
+	return append(rules,
+		Rule{
+			Kinds: []string{"Pod"},
+			Omit:  []string{"spec.enableServiceLinks=true"},
+		},
+		Rule{
+			Kinds: []string{"ReplicationController", "ReplicaSet.apps"},
+			Omit:  []string{"spec.replicas=1"},
+		},
+		Rule{
+			Kinds: []string{"Service"},
+			Omit: []string{
+				"spec.internalTrafficPolicy=Cluster",
+				"spec.ports[*].protocol=TCP",
+				"spec.sessionAffinity=None",
+				"spec.type=ClusterIP",
+			},
+		},
+		Rule{
+			Kinds: []string{"Deployment.apps"},
+			Omit: []string{
+				"spec.replicas=1",
+				"spec.strategy.rollingUpdate.maxSurge=25%",
+				"spec.strategy.rollingUpdate.maxUnavailable=25%",
+				"spec.strategy.rollingUpdate={}",
+				"spec.strategy.type=RollingUpdate",
+				"spec.strategy={}",
+				"spec.revisionHistoryLimit=10",
+				"spec.progressDeadlineSeconds=600",
+			},
+		},
+		Rule{
+			Kinds: []string{"StatefulSet.apps"},
+			Omit: []string{
+				"spec.replicas=1",
+				"spec.podManagementPolicy=OrderedReady",
+				"spec.updateStrategy.rollingUpdate.partition=0",
+				"spec.updateStrategy.rollingUpdate={}",
+				"spec.updateStrategy.type=RollingUpdate",
+				"spec.updateStrategy={}",
+				"spec.revisionHistoryLimit=10",
+				"spec.persistentVolumeClaimRetentionPolicy.whenDeleted=Retain",
+				"spec.persistentVolumeClaimRetentionPolicy.whenScaled=Retain",
+				"spec.persistentVolumeClaimRetentionPolicy={}",
+			},
+		},
+		Rule{
+			Kinds: []string{"DaemonSet.apps"},
+			Omit: []string{
+				"spec.updateStrategy.rollingUpdate.maxSurge=0",
+				"spec.updateStrategy.rollingUpdate.maxUnavailable=1",
+				"spec.updateStrategy.rollingUpdate={}",
+				"spec.updateStrategy.type=RollingUpdate",
+				"spec.updateStrategy={}",
+				"spec.revisionHistoryLimit=10",
+			},
+		},
+		Rule{
+			Kinds: []string{"Job.batch"},
+			Omit:  prefixPaths("spec.", jobSpec),
+		},
+		Rule{
+			Kinds: []string{"CronJob.batch"},
+			Omit: append(prefixPaths("spec.jobTemplate.spec.", jobSpec),
+				"spec.concurrencyPolicy=Allow",
+				"spec.suspend=false",
+				"spec.successfulJobsHistoryLimit=3",
+				"spec.failedJobsHistoryLimit=1",
+			),
+		},
+	)
+}
+
+func prefixPaths(prefix string, paths []string) []string {
+	prefixed := make([]string, 0, len(paths))
+	for _, p := range paths {
+		prefixed = append(prefixed, prefix+p)
+	}
+
+	return prefixed
+}
+
+// omitDefaults omits the fields of the object equal to the defaults the API server would apply.
+// Each field listed by defaultRules is compared with its default value once,
+// and imagePullPolicy is compared with the default for the image of the container.
+// The metadata and status fields are not omitted.
+func omitDefaults(obj *unstructured.Unstructured) {
+	gk := obj.GroupVersionKind().GroupKind()
+
+	for _, rule := range defaultRules() {
+		if !rule.matches(gk.Kind, gk.String()) {
+			continue
+		}
+
+		for _, p := range rule.Omit {
+			p, value := splitValue(p)
+
+			path, err := parsePath(p)
+			if err != nil {
+				continue
+			}
+
+			removeField(obj.Object, path, value)
+		}
+	}
+
+	spec, ok := podSpecPaths()[gk.String()]
+	if !ok {
+		return
+	}
+
+	podSpec, ok, _ := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(spec, ".")...)
+	if !ok {
+		return
+	}
+
+	m, _ := podSpec.(map[string]interface{})
+
+	for _, containers := range []string{"containers", "initContainers"} {
+		items, _ := m[containers].([]interface{})
+		for _, item := range items {
+			c, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			image, _ := c["image"].(string)
+			if policy, _ := c["imagePullPolicy"].(string); policy == defaultImagePullPolicy(image) {
+				delete(c, "imagePullPolicy")
+			}
+		}
+	}
+}
+
+// defaultImagePullPolicy returns Always for the latest or untagged images, IfNotPresent otherwise.
+func defaultImagePullPolicy(image string) string {
+	if strings.Contains(image, "@") {
+		return "IfNotPresent"
+	}
+
+	name := image[strings.LastIndex(image, "/")+1:]
+
+	i := strings.LastIndex(name, ":")
+	if i < 0 || name[i+1:] == "latest" {
+		return "Always"
+	}
+
+	return "IfNotPresent"
+}
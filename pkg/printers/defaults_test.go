@@ -0,0 +1,212 @@
+package printers
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestOmitDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  string
+		want string
+	}{
+		{
+			name: "pod",
+			obj: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+spec:
+  dnsPolicy: ClusterFirst
+  enableServiceLinks: true
+  restartPolicy: Always
+  securityContext: {}
+  containers:
+  - name: nginx
+    image: nginx:1.25
+    imagePullPolicy: IfNotPresent
+    terminationMessagePath: /dev/termination-log
+    ports:
+    - containerPort: 80
+      protocol: TCP
+    readinessProbe:
+      httpGet:
+        port: 80
+        scheme: HTTP
+      periodSeconds: 5
+      timeoutSeconds: 1
+status:
+  phase: Running
+`,
+			want: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: nginx
+spec:
+  containers:
+  - name: nginx
+    image: nginx:1.25
+    ports:
+    - containerPort: 80
+    readinessProbe:
+      httpGet:
+        port: 80
+      periodSeconds: 5
+status:
+  phase: Running
+`,
+		},
+		{
+			name: "image pull policy differing from the default",
+			obj: `
+apiVersion: v1
+kind: Pod
+spec:
+  containers:
+  - image: nginx
+    imagePullPolicy: IfNotPresent
+  - image: nginx:latest
+    imagePullPolicy: Always
+  - image: nginx@sha256:0123
+    imagePullPolicy: Always
+`,
+			want: `
+apiVersion: v1
+kind: Pod
+spec:
+  containers:
+  - image: nginx
+    imagePullPolicy: IfNotPresent
+  - image: nginx:latest
+  - image: nginx@sha256:0123
+    imagePullPolicy: Always
+`,
+		},
+		{
+			name: "deployment",
+			obj: `
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: 3
+  revisionHistoryLimit: 10
+  progressDeadlineSeconds: 600
+  strategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxSurge: 25%
+      maxUnavailable: 25%
+  template:
+    spec:
+      terminationGracePeriodSeconds: 30
+      containers:
+      - image: nginx:1.25
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - image: nginx:1.25
+`,
+		},
+		{
+			name: "deployment with a custom rolling update",
+			obj: `
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  strategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxSurge: 1
+      maxUnavailable: 25%
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  strategy:
+    rollingUpdate:
+      maxSurge: 1
+`,
+		},
+		{
+			name: "cronjob",
+			obj: `
+apiVersion: batch/v1
+kind: CronJob
+spec:
+  schedule: "*/5 * * * *"
+  concurrencyPolicy: Allow
+  suspend: false
+  jobTemplate:
+    spec:
+      backoffLimit: 6
+      completions: 1
+      template:
+        spec:
+          restartPolicy: OnFailure
+`,
+			want: `
+apiVersion: batch/v1
+kind: CronJob
+spec:
+  schedule: "*/5 * * * *"
+  jobTemplate:
+    spec:
+      completions: 1
+      template:
+        spec:
+          restartPolicy: OnFailure
+`,
+		},
+		{
+			name: "unknown kind",
+			obj: `
+apiVersion: example.com/v1
+kind: Widget
+spec:
+  replicas: 1
+  type: ClusterIP
+`,
+			want: `
+apiVersion: example.com/v1
+kind: Widget
+spec:
+  replicas: 1
+  type: ClusterIP
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal([]byte(tt.obj), &obj.Object); err != nil {
+				t.Fatal(err)
+			}
+
+			var want map[string]interface{}
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			omitDefaults(obj)
+
+			if !reflect.DeepEqual(obj.Object, want) {
+				got, _ := yaml.Marshal(obj.Object)
+				t.Errorf("omitDefaults() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
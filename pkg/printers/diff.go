@@ -0,0 +1,198 @@
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/yaml"
+)
+
+const diffContextLines = 3
+
+// Diff prints the unified diff between the live object and its last-applied-configuration annotation,
+// and the unified diff between the previous and the latest revisions of the workload.
+// Implements the printers.ResourcePrinter interface.
+type Diff struct {
+	// Revisions returns the revisions of the workload sorted by the revision number, oldest first.
+	Revisions func(obj *unstructured.Unstructured) ([]kubernetes.Revision, error)
+	// Color colorizes the added, removed and hunk header lines.
+	Color bool
+
+	mu        sync.Mutex
+	revisions map[types.UID][]kubernetes.Revision
+}
+
+var _ printers.ResourcePrinter = (*Diff)(nil)
+
+// PrintObj prints the diffs of the object.
+// The revisions of each object are fetched once and reused.
+func (p *Diff) PrintObj(obj runtime.Object, w io.Writer) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unsupported object type %T", obj)
+	}
+
+	var printed bool
+
+	if lastApplied, ok := u.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; ok {
+		applied := &unstructured.Unstructured{}
+		if err := json.Unmarshal([]byte(lastApplied), &applied.Object); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", corev1.LastAppliedConfigAnnotation, err)
+		}
+
+		if err := p.printDiff(w, normalize(applied), normalize(u),
+			"last-applied-configuration", "live"); err != nil {
+			return err
+		}
+
+		printed = true
+	}
+
+	revisions, err := p.revisionsOf(u)
+	if err != nil {
+		return err
+	}
+
+	if n := len(revisions); n >= 2 { //nolint:gomnd
+		previous, latest := revisions[n-2], revisions[n-1]
+
+		if printed {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		if err := p.printDiff(w, previous.Template, latest.Template,
+			revisionName(previous), revisionName(latest)); err != nil {
+			return err
+		}
+
+		printed = true
+	}
+
+	if !printed {
+		_, _ = fmt.Fprintln(w, "# no last-applied-configuration annotation or previous revision to compare")
+	}
+
+	return nil
+}
+
+func (p *Diff) revisionsOf(u *unstructured.Unstructured) ([]kubernetes.Revision, error) {
+	if p.Revisions == nil {
+		return nil, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if revisions, ok := p.revisions[u.GetUID()]; ok {
+		return revisions, nil
+	}
+
+	revisions, err := p.Revisions(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.revisions == nil {
+		p.revisions = make(map[types.UID][]kubernetes.Revision)
+	}
+
+	p.revisions[u.GetUID()] = revisions
+
+	return revisions, nil
+}
+
+func (p *Diff) printDiff(w io.Writer, a, b interface{}, fromName, toName string) error {
+	from, err := yaml.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("failed to encode object: %w", err)
+	}
+
+	to, err := yaml.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("failed to encode object: %w", err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(string(from), "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(string(to), "\n")),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  diffContextLines,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to diff: %w", err)
+	}
+
+	if diff == "" {
+		_, _ = fmt.Fprintf(w, "# no differences between %s and %s\n", fromName, toName)
+
+		return nil
+	}
+
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if line == "" {
+			continue
+		}
+
+		_, _ = io.WriteString(w, p.colorLine(line))
+	}
+
+	return nil
+}
+
+// colorLine colorizes the line of the unified diff.
+func (p *Diff) colorLine(line string) string {
+	if !p.Color {
+		return line
+	}
+
+	var sgr string
+
+	switch {
+	case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+		sgr = "1"
+	case strings.HasPrefix(line, "@@"):
+		sgr = "36"
+	case strings.HasPrefix(line, "+"):
+		sgr = "32"
+	case strings.HasPrefix(line, "-"):
+		sgr = "31"
+	default:
+		return line
+	}
+
+	return "\x1b[" + sgr + "m" + strings.TrimSuffix(line, "\n") + sgrReset + "\n"
+}
+
+// normalize returns the object without the server-managed fields so that only the fields
+// changed since the last apply are shown.
+// The fields equal to the defaults the API server would apply are omitted
+// because the live object contains them but the last-applied configuration usually does not.
+func normalize(u *unstructured.Unstructured) interface{} {
+	stripped, ok := Strip(u).(*unstructured.Unstructured)
+	if !ok {
+		return u.Object
+	}
+
+	if len(stripped.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(stripped.Object, "metadata", "annotations")
+	}
+
+	omitDefaults(stripped)
+
+	return stripped.Object
+}
+
+func revisionName(r kubernetes.Revision) string {
+	return fmt.Sprintf("%s/%s (revision %d)", strings.ToLower(r.Kind), r.Name, r.Number)
+}
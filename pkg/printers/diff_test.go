@@ -0,0 +1,128 @@
+package printers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiffPrintObj(t *testing.T) {
+	const live = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  uid: web
+  resourceVersion: "42"
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: |
+      {"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web"},"spec":{"replicas":2}}
+spec:
+  replicas: 3
+  revisionHistoryLimit: 10
+status:
+  replicas: 3
+`
+
+	revisions := []kubernetes.Revision{
+		{Name: "web-1", Kind: "ReplicaSet", Number: 1, Template: map[string]interface{}{"image": "nginx:1.24"}},
+		{Name: "web-2", Kind: "ReplicaSet", Number: 2, Template: map[string]interface{}{"image": "nginx:1.25"}},
+	}
+
+	tests := []struct {
+		name      string
+		obj       string
+		revisions []kubernetes.Revision
+		color     bool
+		want      string
+	}{
+		{
+			name: "last-applied-configuration",
+			obj:  live,
+			want: `--- last-applied-configuration
++++ live
+@@ -3,4 +3,4 @@
+ metadata:
+   name: web
+ spec:
+-  replicas: 2
++  replicas: 3
+`,
+		},
+		{
+			name:      "last-applied-configuration and revisions",
+			obj:       live,
+			revisions: revisions,
+			want: `--- last-applied-configuration
++++ live
+@@ -3,4 +3,4 @@
+ metadata:
+   name: web
+ spec:
+-  replicas: 2
++  replicas: 3
+
+--- replicaset/web-1 (revision 1)
++++ replicaset/web-2 (revision 2)
+@@ -1 +1 @@
+-image: nginx:1.24
++image: nginx:1.25
+`,
+		},
+		{
+			name:      "single revision",
+			obj:       `{kind: Deployment, metadata: {name: web}}`,
+			revisions: revisions[:1],
+			want:      "# no last-applied-configuration annotation or previous revision to compare\n",
+		},
+		{
+			name:      "no differences",
+			obj:       `{kind: Deployment, metadata: {name: web}}`,
+			revisions: []kubernetes.Revision{revisions[0], {Name: "web-2", Kind: "ReplicaSet", Number: 2, Template: revisions[0].Template}},
+			want:      "# no differences between replicaset/web-1 (revision 1) and replicaset/web-2 (revision 2)\n",
+		},
+		{
+			name:      "color",
+			obj:       `{kind: Deployment, metadata: {name: web}}`,
+			revisions: revisions,
+			color:     true,
+			want: "\x1b[1m--- replicaset/web-1 (revision 1)\x1b[0m\n" +
+				"\x1b[1m+++ replicaset/web-2 (revision 2)\x1b[0m\n" +
+				"\x1b[36m@@ -1 +1 @@\x1b[0m\n" +
+				"\x1b[31m-image: nginx:1.24\x1b[0m\n" +
+				"\x1b[32m+image: nginx:1.25\x1b[0m\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+
+			p := &Diff{
+				Revisions: func(*unstructured.Unstructured) ([]kubernetes.Revision, error) {
+					calls++
+
+					return tt.revisions, nil
+				},
+				Color: tt.color,
+			}
+
+			for i := 0; i < 2; i++ {
+				var buf bytes.Buffer
+				if err := p.PrintObj(decodeObject(t, tt.obj), &buf); err != nil {
+					t.Fatalf("PrintObj() error = %v", err)
+				}
+
+				if buf.String() != tt.want {
+					t.Errorf("PrintObj() =\n%q\nwant\n%q", buf.String(), tt.want)
+				}
+			}
+
+			if calls != 1 {
+				t.Errorf("Revisions() called %d times, want once", calls)
+			}
+		})
+	}
+}
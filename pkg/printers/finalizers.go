@@ -0,0 +1,43 @@
+package printers
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// Finalizers wraps an existing printer and prints the deletion timestamp and the finalizers of the object
+// before printing it.
+// Implements the printers.ResourcePrinter interface.
+type Finalizers struct {
+	Delegate printers.ResourcePrinter
+}
+
+var _ printers.ResourcePrinter = (*Finalizers)(nil)
+
+// PrintObj prints the deletion timestamp and the finalizers followed by the object.
+func (p *Finalizers) PrintObj(obj runtime.Object, w io.Writer) error {
+	a, err := meta.Accessor(obj)
+	if err != nil {
+		return p.Delegate.PrintObj(obj, w)
+	}
+
+	if ts := a.GetDeletionTimestamp(); ts != nil {
+		_, _ = fmt.Fprintf(w, "# deletionTimestamp: %s (%s ago)\n",
+			ts.Format(time.RFC3339), time.Since(ts.Time).Round(time.Second))
+	}
+
+	_, _ = fmt.Fprintln(w, "# finalizers:")
+
+	for _, f := range a.GetFinalizers() {
+		_, _ = fmt.Fprintf(w, "# - %s\n", f)
+	}
+
+	_, _ = fmt.Fprintln(w)
+
+	return p.Delegate.PrintObj(obj, w)
+}
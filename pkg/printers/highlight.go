@@ -0,0 +1,198 @@
+package printers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	// ThemeDefault is the name of the default theme.
+	ThemeDefault = "default"
+	// ThemeLight is the name of the theme for terminals with a light background.
+	ThemeLight = "light"
+	// ThemeMonokai is the name of the theme based on Monokai.
+	ThemeMonokai = "monokai"
+
+	sgrReset = "\x1b[0m"
+)
+
+// Theme represents the ANSI SGR parameters of each token (e.g. "34" or "38;5;81").
+// Only the 16 basic colors (30-37) and the 256 colors (38;5;n) are used
+// because the preview window of the fuzzy finder does not render the bright colors (90-97).
+type Theme struct {
+	Key     string
+	String  string
+	Number  string
+	Bool    string
+	Null    string
+	Comment string
+}
+
+// Themes returns the built-in themes by name.
+func Themes() map[string]Theme {
+	return map[string]Theme{
+		ThemeDefault: {Key: "34", String: "32", Number: "35", Bool: "33", Null: "33", Comment: "2"},
+		ThemeLight:   {Key: "34;1", String: "31", Number: "35", Bool: "36", Null: "36", Comment: "2"},
+		ThemeMonokai: {
+			Key: "38;5;197", String: "38;5;186", Number: "38;5;141", Bool: "38;5;81", Null: "38;5;81",
+			Comment: "38;5;242",
+		},
+	}
+}
+
+// ThemeNames returns the sorted names of the built-in themes.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes()))
+	for name := range Themes() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Highlight wraps an existing YAML or JSON printer and colorizes the keys, strings, numbers,
+// booleans and nulls of the output with ANSI escape sequences.
+// Lines that are neither YAML nor JSON (e.g. the tree format) and lines already colorized
+// (e.g. the diff format) are printed as they are.
+// Implements the printers.ResourcePrinter interface.
+type Highlight struct {
+	Delegate printers.ResourcePrinter
+	Theme    Theme
+}
+
+var _ printers.ResourcePrinter = (*Highlight)(nil)
+
+// keyValue matches a line consisting of the indentation, list markers, a key, a colon and a value.
+var keyValue = regexp.MustCompile( //nolint:gochecknoglobals
+	`^(\s*(?:- )*)("(?:[^"\\]|\\.)*"|'[^']*'|[^\s#'"{}\[\],-][^:#]*?)(:(?:\s+|$))(.*)$`)
+
+// listItem matches a line consisting of the indentation, list markers and a value.
+var listItem = regexp.MustCompile(`^(\s*(?:- )+)(.*)$`) //nolint:gochecknoglobals
+
+// number matches the JSON and YAML numbers.
+var number = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][-+]?[0-9]+)?$`) //nolint:gochecknoglobals
+
+// PrintObj prints the object with the delegate printer and colorizes the output.
+func (p *Highlight) PrintObj(obj runtime.Object, w io.Writer) error {
+	var buf bytes.Buffer
+
+	if err := p.Delegate.PrintObj(obj, &buf); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	// the indentation of the key of the block scalar (e.g. "key: |") being printed, -1 if none
+	blockIndent := -1
+
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(nil, len(buf.Bytes())+1)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) == "" || indent > blockIndent {
+				_, _ = fmt.Fprintln(bw, p.color(p.Theme.String, line))
+
+				continue
+			}
+
+			blockIndent = -1
+		}
+
+		colored, block := p.highlightLine(line)
+		if block {
+			blockIndent = indent
+		}
+
+		_, _ = fmt.Fprintln(bw, colored)
+	}
+
+	return bw.Flush()
+}
+
+// highlightLine colorizes a line and reports whether the line starts a block scalar.
+func (p *Highlight) highlightLine(line string) (string, bool) {
+	if strings.Contains(line, "\x1b[") {
+		return line, false
+	}
+
+	if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "#") {
+		return p.color(p.Theme.Comment, line), false
+	}
+
+	m := keyValue.FindStringSubmatch(line)
+	if m == nil {
+		if m = listItem.FindStringSubmatch(line); m != nil {
+			return m[1] + p.value(m[2]), false
+		}
+
+		if isJSONValue(line) {
+			// an element of a JSON array
+			trimmed := strings.TrimLeft(line, " ")
+
+			return line[:len(line)-len(trimmed)] + p.value(trimmed), false
+		}
+
+		return line, false
+	}
+
+	prefix, key, colon, value := m[1], m[2], m[3], m[4]
+
+	block := strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">")
+
+	if block {
+		return prefix + p.color(p.Theme.Key, key) + colon + value, true
+	}
+
+	return prefix + p.color(p.Theme.Key, key) + colon + p.value(value), false
+}
+
+// value colorizes a scalar value followed by an optional trailing comma of JSON.
+func (p *Highlight) value(v string) string {
+	trailing := ""
+
+	if strings.HasSuffix(v, ",") {
+		v, trailing = strings.TrimSuffix(v, ","), ","
+	}
+
+	switch {
+	case v == "", v == "{", v == "}", v == "[", v == "]", v == "{}", v == "[]":
+		return v + trailing
+	case v == "true", v == "false":
+		return p.color(p.Theme.Bool, v) + trailing
+	case v == "null", v == "~":
+		return p.color(p.Theme.Null, v) + trailing
+	case number.MatchString(v):
+		return p.color(p.Theme.Number, v) + trailing
+	default:
+		return p.color(p.Theme.String, v) + trailing
+	}
+}
+
+func (p *Highlight) color(sgr, s string) string {
+	if sgr == "" || s == "" {
+		return s
+	}
+
+	return "\x1b[" + sgr + "m" + s + sgrReset
+}
+
+// isJSONValue reports whether the line is a JSON scalar value.
+func isJSONValue(s string) bool {
+	s = strings.TrimSuffix(strings.TrimSpace(s), ",")
+
+	return strings.HasPrefix(s, `"`) || s == "true" || s == "false" || s == "null" || number.MatchString(s)
+}
@@ -0,0 +1,53 @@
+package printers
+
+import (
+	"testing"
+)
+
+func TestHighlightLine(t *testing.T) {
+	p := &Highlight{Theme: Theme{Key: "k", String: "s", Number: "n", Bool: "b", Null: "z", Comment: "c"}}
+
+	c := func(sgr, s string) string {
+		return "\x1b[" + sgr + "m" + s + sgrReset
+	}
+
+	tests := []struct {
+		name      string
+		line      string
+		want      string
+		wantBlock bool
+	}{
+		{name: "string", line: "  name: nginx", want: "  " + c("k", "name") + ": " + c("s", "nginx")},
+		{name: "number", line: "replicas: 3", want: c("k", "replicas") + ": " + c("n", "3")},
+		{name: "negative float", line: "x: -1.5e3", want: c("k", "x") + ": " + c("n", "-1.5e3")},
+		{name: "bool", line: "suspend: false", want: c("k", "suspend") + ": " + c("b", "false")},
+		{name: "null", line: "value: null", want: c("k", "value") + ": " + c("z", "null")},
+		{name: "tilde", line: "value: ~", want: c("k", "value") + ": " + c("z", "~")},
+		{name: "mapping", line: "metadata:", want: c("k", "metadata") + ":"},
+		{name: "empty list", line: "args: []", want: c("k", "args") + ": []"},
+		{name: "list item key", line: "- name: app", want: "- " + c("k", "name") + ": " + c("s", "app")},
+		{name: "list item value", line: "  - --verbose", want: "  - " + c("s", "--verbose")},
+		{name: "comment", line: "  # comment", want: c("c", "  # comment")},
+		{name: "block scalar", line: "  script: |", want: "  " + c("k", "script") + ": |", wantBlock: true},
+		{name: "folded block scalar", line: "text: >-", want: c("k", "text") + ": >-", wantBlock: true},
+		{
+			name: "json key",
+			line: `    "replicas": 3,`,
+			want: "    " + c("k", `"replicas"`) + ": " + c("n", "3") + ",",
+		},
+		{name: "json open", line: `  "spec": {`, want: "  " + c("k", `"spec"`) + ": {"},
+		{name: "json array element", line: `    "a",`, want: "    " + c("s", `"a"`) + ","},
+		{name: "json close", line: "  },", want: "  },"},
+		{name: "tree line", line: "Deployment/nginx", want: "Deployment/nginx"},
+		{name: "already colorized", line: "\x1b[31m-  name: a\x1b[0m", want: "\x1b[31m-  name: a\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, block := p.highlightLine(tt.line)
+			if got != tt.want || block != tt.wantBlock {
+				t.Errorf("highlightLine(%q) = %q, %v, want %q, %v", tt.line, got, block, tt.want, tt.wantBlock)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package printers
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// RuleSetNeat is the name of the built-in rule set mimicking kubectl-neat.
+	// It omits the fields set by the API server and the controllers.
+	RuleSetNeat = "neat"
+)
+
+// podSpecPaths is the paths of the pod specs of the workload kinds.
+func podSpecPaths() map[string]string {
+	return map[string]string{
+		"Pod":                   "spec",
+		"PodTemplate":           "template.spec",
+		"ReplicationController": "spec.template.spec",
+		"ReplicaSet.apps":       "spec.template.spec",
+		"Deployment.apps":       "spec.template.spec",
+		"StatefulSet.apps":      "spec.template.spec",
+		"DaemonSet.apps":        "spec.template.spec",
+		"Job.batch":             "spec.template.spec",
+		"CronJob.batch":         "spec.jobTemplate.spec.template.spec",
+	}
+}
+
+// neatRules returns the rules omitting the fields set by the API server and the controllers.
+// The fields equal to the defaults are omitted by the defaults rule set included at the end,
+// so that the defaults are listed only in defaultRules.
+func neatRules() Rules {
+	specs := podSpecPaths()
+
+	kinds := make([]string, 0, len(specs))
+	for kind := range specs {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Strings(kinds)
+
+	var rules Rules
+
+	for _, kind := range kinds {
+		spec := specs[kind]
+
+		omit := []string{spec + ".serviceAccount"}
+
+		for _, containers := range []string{"containers", "initContainers"} {
+			omit = append(omit, spec+"."+containers+"[*].resources={}")
+		}
+
+		if template := strings.TrimSuffix(spec, "spec"); template != "" {
+			omit = append(omit, template+"metadata.creationTimestamp")
+		}
+
+		rules = append(rules, Rule{Kinds: []string{kind}, Omit: omit})
+	}
+
+	return append(rules,
+		Rule{
+			Kinds: []string{"Deployment.apps"},
+			Omit:  []string{`metadata.annotations.deployment\.kubernetes\.io/revision`},
+		},
+		Rule{
+			Kinds: []string{"Pod"},
+			Omit: []string{
+				"spec.preemptionPolicy=PreemptLowerPriority",
+				"spec.priority=0",
+			},
+		},
+		Rule{
+			Kinds: []string{"Service"},
+			Omit: []string{
+				"spec.clusterIP",
+				"spec.clusterIPs",
+				"spec.ipFamilies",
+				"spec.ipFamilyPolicy=SingleStack",
+			},
+		},
+		Rule{OmitDefaults: true},
+	)
+}
@@ -0,0 +1,100 @@
+package printers
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func TestNeatRules(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  string
+		want string
+	}{
+		{
+			name: "deployment",
+			obj: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+spec:
+  replicas: 2
+  progressDeadlineSeconds: 600
+  template:
+    metadata:
+      creationTimestamp: null
+    spec:
+      dnsPolicy: ClusterFirst
+      serviceAccount: default
+      containers:
+      - image: nginx:latest
+        imagePullPolicy: Always
+        resources: {}
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  annotations: {}
+spec:
+  replicas: 2
+  template:
+    metadata: {}
+    spec:
+      containers:
+      - image: nginx:latest
+`,
+		},
+		{
+			name: "service",
+			obj: `
+apiVersion: v1
+kind: Service
+spec:
+  type: ClusterIP
+  clusterIP: 10.0.0.1
+  clusterIPs: [10.0.0.1]
+  ipFamilyPolicy: SingleStack
+  ports:
+  - port: 80
+    protocol: TCP
+`,
+			want: `
+apiVersion: v1
+kind: Service
+spec:
+  ports:
+  - port: 80
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			if err := yaml.Unmarshal([]byte(tt.obj), &obj.Object); err != nil {
+				t.Fatal(err)
+			}
+
+			var want map[string]interface{}
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			simplified := obj.DeepCopy()
+			neatRules().apply(obj, simplified)
+
+			if !reflect.DeepEqual(simplified.Object, want) {
+				got, _ := yaml.Marshal(simplified.Object)
+				t.Errorf("neatRules() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Rule is a simplification rule applied to the objects of the kinds.
+//
+// A field path is a dot separated list of field names (e.g. spec.template.metadata.creationTimestamp).
+// A field name followed by "[*]" applies the rest of the path to each element of the list
+// (e.g. spec.containers[*].terminationMessagePath) and dots in a field name are escaped with a backslash
+// (e.g. metadata.annotations.deployment\.kubernetes\.io/revision).
+type Rule struct {
+	// Kinds is the list of kinds in the form of "Kind" or "Kind.group" (e.g. Deployment.apps) the rule applies to.
+	// "*" or an empty list applies the rule to all kinds.
+	Kinds []string `json:"kinds,omitempty"`
+	// Omit is the list of field paths to omit.
+	// A field path followed by "=value" omits the field only if it is equal to the value (e.g. spec.dnsPolicy=ClusterFirst).
+	// Non-string values are compared with their JSON representation (e.g. spec.securityContext={}).
+	Omit []string `json:"omit,omitempty"`
+	// Keep is the list of field paths to keep even if they are omitted by the default simplification
+	// or by the Omit field paths (e.g. status.conditions).
+	Keep []string `json:"keep,omitempty"`
+	// OmitDefaults omits the fields equal to the defaults the API server would apply.
+	// Only the defaults of the commonly used kinds (e.g. Pod, Deployment, Service) are known.
+	OmitDefaults bool `json:"omitDefaults,omitempty"`
+}
+
+// Rules is the list of simplification rules applied in order.
+type Rules []Rule
+
+// BuiltinRules returns the built-in rule set with the name.
+func BuiltinRules(name string) (Rules, error) {
+	switch name {
+	case RuleSetNeat:
+		return neatRules(), nil
+	case RuleSetDefaults:
+		return Rules{{OmitDefaults: true}}, nil
+	default:
+		return nil, fmt.Errorf("unknown rule set %q, must be one of %s|%s", name, RuleSetNeat, RuleSetDefaults)
+	}
+}
+
+// Validate ensures that all field paths of the rules are valid.
+func (r Rules) Validate() error {
+	for _, rule := range r {
+		for _, p := range rule.Omit {
+			path, _ := splitValue(p)
+			if _, err := parsePath(path); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range rule.Keep {
+			if _, err := parsePath(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// apply applies the rules matching the kind of the original object to the simplified object.
+// The Keep field paths are copied from the original object.
+func (r Rules) apply(original, simplified runtime.Object) {
+	o, ok := original.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	s, ok := simplified.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	gk := o.GroupVersionKind().GroupKind()
+
+	var (
+		keep     []string
+		defaults bool
+	)
+
+	for _, rule := range r {
+		if !rule.matches(gk.Kind, gk.String()) {
+			continue
+		}
+
+		for _, p := range rule.Omit {
+			p, value := splitValue(p)
+
+			path, err := parsePath(p)
+			if err != nil {
+				continue
+			}
+
+			removeField(s.Object, path, value)
+		}
+
+		keep = append(keep, rule.Keep...)
+		defaults = defaults || rule.OmitDefaults
+	}
+
+	if defaults {
+		omitDefaults(s)
+	}
+
+	for _, p := range keep {
+		path, err := parsePath(p)
+		if err != nil {
+			continue
+		}
+
+		copyField(s.Object, o.Object, path)
+	}
+}
+
+func (r Rule) matches(kind, groupKind string) bool {
+	if len(r.Kinds) == 0 {
+		return true
+	}
+
+	for _, k := range r.Kinds {
+		if k == "*" || strings.EqualFold(k, kind) || strings.EqualFold(k, groupKind) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// segment is a field name of a field path.
+type segment struct {
+	name string
+	// each applies the rest of the path to each element of the list.
+	each bool
+}
+
+// parsePath parses the dot separated field path.
+func parsePath(path string) ([]segment, error) {
+	var (
+		segments []segment
+		name     strings.Builder
+	)
+
+	appendSegment := func() error {
+		s := segment{name: name.String()}
+		name.Reset()
+
+		if strings.HasSuffix(s.name, "[*]") {
+			s.name = strings.TrimSuffix(s.name, "[*]")
+			s.each = true
+		}
+
+		if s.name == "" {
+			return fmt.Errorf("invalid field path %q: empty field name", path)
+		}
+
+		segments = append(segments, s)
+
+		return nil
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '\\' && i+1 < len(path):
+			i++
+			name.WriteByte(path[i])
+		case c == '.':
+			if err := appendSegment(); err != nil {
+				return nil, err
+			}
+		default:
+			name.WriteByte(c)
+		}
+	}
+
+	if err := appendSegment(); err != nil {
+		return nil, err
+	}
+
+	return segments, nil
+}
+
+// splitValue splits the "path=value" into the path and the value.
+// The value is nil if the path has no value.
+func splitValue(s string) (string, *string) {
+	path, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return s, nil
+	}
+
+	return path, &value
+}
+
+// removeField removes the field at the path if the value is nil or equal to the value of the field.
+func removeField(obj map[string]interface{}, path []segment, value *string) {
+	s := path[0]
+
+	v, ok := obj[s.name]
+	if !ok {
+		return
+	}
+
+	if len(path) == 1 {
+		if value == nil || equalValue(v, *value) {
+			delete(obj, s.name)
+		}
+
+		return
+	}
+
+	if !s.each {
+		if child, ok := v.(map[string]interface{}); ok {
+			removeField(child, path[1:], value)
+		}
+
+		return
+	}
+
+	items, _ := v.([]interface{})
+	for _, item := range items {
+		if child, ok := item.(map[string]interface{}); ok {
+			removeField(child, path[1:], value)
+		}
+	}
+}
+
+// copyField copies the field at the path from the src to the dst.
+// Each element of a list is copied only if the lists of the src and the dst have the same length.
+func copyField(dst, src map[string]interface{}, path []segment) {
+	s := path[0]
+
+	v, ok := src[s.name]
+	if !ok {
+		return
+	}
+
+	if len(path) == 1 {
+		dst[s.name] = runtime.DeepCopyJSONValue(v)
+
+		return
+	}
+
+	if !s.each {
+		srcChild, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		dstChild, ok := dst[s.name].(map[string]interface{})
+		if !ok {
+			dstChild = make(map[string]interface{})
+			dst[s.name] = dstChild
+		}
+
+		copyField(dstChild, srcChild, path[1:])
+
+		return
+	}
+
+	srcItems, _ := v.([]interface{})
+	dstItems, _ := dst[s.name].([]interface{})
+
+	if len(srcItems) != len(dstItems) {
+		return
+	}
+
+	for i := range srcItems {
+		srcChild, ok := srcItems[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		dstChild, ok := dstItems[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		copyField(dstChild, srcChild, path[1:])
+	}
+}
+
+// equalValue reports whether the value is equal to the string.
+// Non-string values are compared with their JSON representation.
+func equalValue(v interface{}, s string) bool {
+	if str, ok := v.(string); ok {
+		return str == s
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+
+	return string(b) == s
+}
@@ -12,9 +12,11 @@ import (
 )
 
 // Simplify wraps an existing printer and omits the metadata and status fields from the object before printing it.
+// The Rules are applied after the metadata and status fields are omitted.
 // Implements the printers.ResourcePrinter interface.
 type Simplify struct {
 	Delegate printers.ResourcePrinter
+	Rules    Rules
 }
 
 var _ printers.ResourcePrinter = (*Simplify)(nil)
@@ -90,6 +92,29 @@ func (p *Simplify) PrintObj(obj runtime.Object, w io.Writer) error {
 		return p.Delegate.PrintObj(obj, w)
 	}
 
+	simplified := Strip(obj)
+
+	if len(p.Rules) > 0 {
+		if meta.IsListType(obj) {
+			originals, _ := meta.ExtractList(obj)
+			simplifiedItems, _ := meta.ExtractList(simplified)
+
+			for i := range originals {
+				if i < len(simplifiedItems) {
+					p.Rules.apply(originals[i], simplifiedItems[i])
+				}
+			}
+		} else {
+			p.Rules.apply(obj, simplified)
+		}
+	}
+
+	return p.Delegate.PrintObj(simplified, w)
+}
+
+// Strip returns a copy of the object without the server-managed metadata and status fields.
+// If the object is a list, the fields are omitted from each item.
+func Strip(obj runtime.Object) runtime.Object {
 	if meta.IsListType(obj) {
 		obj = obj.DeepCopyObject()
 		_ = meta.EachListItem(obj, func(item runtime.Object) error {
@@ -105,5 +130,5 @@ func (p *Simplify) PrintObj(obj runtime.Object, w io.Writer) error {
 		obj = omitStatus(obj)
 	}
 
-	return p.Delegate.PrintObj(obj, w)
+	return obj
 }
@@ -0,0 +1,153 @@
+package printers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// Tree prints the object and its dependents found by following the owner references as a tree
+// along with the status of each object.
+// Implements the printers.ResourcePrinter interface.
+type Tree struct {
+	// NewGraph returns the owner graph of the objects in the namespace.
+	// An empty namespace means all namespaces.
+	NewGraph func(namespace string) (*kubernetes.OwnerGraph, error)
+
+	mu     sync.Mutex
+	graphs map[string]*kubernetes.OwnerGraph
+}
+
+var _ printers.ResourcePrinter = (*Tree)(nil)
+
+// PrintObj prints the dependency tree of the object.
+// The owner graph of each namespace is built once and reused.
+func (p *Tree) PrintObj(obj runtime.Object, w io.Writer) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unsupported object type %T", obj)
+	}
+
+	graph, err := p.graph(u.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	tw := printers.GetNewTabWriter(w)
+
+	_, _ = fmt.Fprintf(tw, "%s\t%s\n", nodeName(u), ObjectStatus(u))
+	printChildren(tw, graph, u.GetUID(), "", map[types.UID]bool{u.GetUID(): true})
+
+	return tw.Flush()
+}
+
+func (p *Tree) graph(namespace string) (*kubernetes.OwnerGraph, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if g, ok := p.graphs[namespace]; ok {
+		return g, nil
+	}
+
+	g, err := p.NewGraph(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dependents: %w", err)
+	}
+
+	if p.graphs == nil {
+		p.graphs = make(map[string]*kubernetes.OwnerGraph)
+	}
+
+	p.graphs[namespace] = g
+
+	return g, nil
+}
+
+func printChildren(w io.Writer, graph *kubernetes.OwnerGraph, uid types.UID, indent string, visited map[types.UID]bool) {
+	children := graph.Children(uid)
+
+	for i, child := range children {
+		branch, next := "├── ", "│   "
+		if i == len(children)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		_, _ = fmt.Fprintf(w, "%s%s%s\t%s\n", indent, branch, nodeName(child), ObjectStatus(child))
+
+		if visited[child.GetUID()] {
+			continue
+		}
+
+		visited[child.GetUID()] = true
+
+		printChildren(w, graph, child.GetUID(), indent+next, visited)
+	}
+}
+
+func nodeName(u *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s", u.GetKind(), u.GetName())
+}
+
+// ObjectStatus returns a short human readable status of the object.
+// It is derived from the common status fields: phase, replicas and conditions.
+func ObjectStatus(u *unstructured.Unstructured) string {
+	if a, err := meta.Accessor(u); err == nil && a.GetDeletionTimestamp() != nil {
+		return "Terminating"
+	}
+
+	var parts []string
+
+	if phase, ok, _ := unstructured.NestedString(u.Object, "status", "phase"); ok {
+		parts = append(parts, phase)
+	}
+
+	if replicas, ok, _ := unstructured.NestedInt64(u.Object, "status", "replicas"); ok {
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		parts = append(parts, fmt.Sprintf("%d/%d ready", ready, replicas))
+	}
+
+	if len(parts) == 0 {
+		if condition := readyCondition(u); condition != "" {
+			parts = append(parts, condition)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "-"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// readyCondition returns the status of the Ready or Available condition, if any.
+func readyCondition(u *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		conditionType, _ := condition["type"].(string)
+		if conditionType != "Ready" && conditionType != "Available" {
+			continue
+		}
+
+		if status, _ := condition["status"].(string); status == "True" {
+			return conditionType
+		}
+
+		return "Not" + conditionType
+	}
+
+	return ""
+}
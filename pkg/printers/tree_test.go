@@ -0,0 +1,197 @@
+package printers
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/d-kuro/kubectl-fuzzy/pkg/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/yaml"
+)
+
+func TestTreePrintObj(t *testing.T) {
+	objs := map[string]string{
+		"deploy": `
+kind: Deployment
+metadata: {name: web, namespace: demo, uid: deploy}
+status: {replicas: 2, readyReplicas: 1}
+`,
+		"rs": `
+kind: ReplicaSet
+metadata:
+  name: web-1
+  namespace: demo
+  uid: rs
+  ownerReferences: [{uid: deploy}]
+status: {replicas: 2, readyReplicas: 1}
+`,
+		"pod-1": `
+kind: Pod
+metadata:
+  name: web-1-a
+  namespace: demo
+  uid: pod-1
+  ownerReferences: [{uid: rs}]
+status: {phase: Running}
+`,
+		"pod-2": `
+kind: Pod
+metadata:
+  name: web-1-b
+  namespace: demo
+  uid: pod-2
+  ownerReferences: [{uid: rs}]
+  deletionTimestamp: "2024-01-02T03:04:05Z"
+status: {phase: Running}
+`,
+		"a": `
+kind: ConfigMap
+metadata:
+  name: a
+  namespace: demo
+  uid: a
+  ownerReferences: [{uid: b}]
+`,
+		"b": `
+kind: ConfigMap
+metadata:
+  name: b
+  namespace: demo
+  uid: b
+  ownerReferences: [{uid: a}]
+`,
+	}
+
+	decode := func(uid string) *unstructured.Unstructured {
+		return decodeObject(t, objs[uid])
+	}
+
+	graph := kubernetes.OwnerGraphOf(decode("rs"), decode("pod-1"), decode("pod-2"), decode("a"), decode("b"))
+
+	tests := []struct {
+		name string
+		uid  string
+		want string
+	}{
+		{
+			name: "nested dependents",
+			uid:  "deploy",
+			want: `Deployment/web         1/2 ready
+└── ReplicaSet/web-1   1/2 ready
+    ├── Pod/web-1-a    Running
+    └── Pod/web-1-b    Terminating
+`,
+		},
+		{
+			name: "no dependents",
+			uid:  "pod-1",
+			want: "Pod/web-1-a   Running\n",
+		},
+		{
+			name: "cycle",
+			uid:  "a",
+			want: `ConfigMap/a           -
+└── ConfigMap/b       -
+    └── ConfigMap/a   -
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var namespaces []string
+
+			p := &Tree{NewGraph: func(namespace string) (*kubernetes.OwnerGraph, error) {
+				namespaces = append(namespaces, namespace)
+
+				return graph, nil
+			}}
+
+			for i := 0; i < 2; i++ {
+				var buf bytes.Buffer
+				if err := p.PrintObj(decode(tt.uid), &buf); err != nil {
+					t.Fatalf("PrintObj() error = %v", err)
+				}
+
+				if buf.String() != tt.want {
+					t.Errorf("PrintObj() =\n%s\nwant\n%s", buf.String(), tt.want)
+				}
+			}
+
+			if len(namespaces) != 1 || namespaces[0] != "demo" {
+				t.Errorf("NewGraph() called for %q, want once for %q", namespaces, "demo")
+			}
+		})
+	}
+}
+
+func TestTreePrintObjGraphError(t *testing.T) {
+	p := &Tree{NewGraph: func(string) (*kubernetes.OwnerGraph, error) {
+		return nil, errors.New("forbidden")
+	}}
+
+	err := p.PrintObj(&unstructured.Unstructured{Object: map[string]interface{}{"kind": "Pod"}}, &bytes.Buffer{})
+	if err == nil || err.Error() != "failed to list dependents: forbidden" {
+		t.Errorf("PrintObj() error = %v, want %q", err, "failed to list dependents: forbidden")
+	}
+}
+
+func TestObjectStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  string
+		want string
+	}{
+		{name: "no status", obj: `kind: ConfigMap`, want: "-"},
+		{name: "phase", obj: `status: {phase: Pending}`, want: "Pending"},
+		{name: "replicas", obj: `status: {replicas: 3, readyReplicas: 3}`, want: "3/3 ready"},
+		{name: "no ready replicas", obj: `status: {replicas: 3}`, want: "0/3 ready"},
+		{
+			name: "terminating",
+			obj:  `{metadata: {deletionTimestamp: "2024-01-02T03:04:05Z"}, status: {phase: Running}}`,
+			want: "Terminating",
+		},
+		{
+			name: "ready condition",
+			obj:  `status: {conditions: [{type: Progressing, status: "True"}, {type: Ready, status: "True"}]}`,
+			want: "Ready",
+		},
+		{
+			name: "available condition false",
+			obj:  `status: {conditions: [{type: Available, status: "False"}]}`,
+			want: "NotAvailable",
+		},
+		{
+			name: "phase before conditions",
+			obj:  `status: {phase: Bound, conditions: [{type: Ready, status: "False"}]}`,
+			want: "Bound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ObjectStatus(decodeObject(t, tt.obj)); got != tt.want {
+				t.Errorf("ObjectStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// decodeObject decodes the YAML object with the numbers as int64 like the objects of the dynamic client.
+func decodeObject(t *testing.T, s string) *unstructured.Unstructured {
+	t.Helper()
+
+	b, err := yaml.YAMLToJSON([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u := &unstructured.Unstructured{}
+	if err := utiljson.Unmarshal(b, &u.Object); err != nil {
+		t.Fatal(err)
+	}
+
+	return u
+}